@@ -3,12 +3,15 @@ package main
 import (
 	"context"
 	"fullcycle-auction_go/configuration/database/mongodb"
+	"fullcycle-auction_go/configuration/metrics"
+	"fullcycle-auction_go/internal/entity/user_entity"
 	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/bid_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/user_controller"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/infra/database/bid"
 	"fullcycle-auction_go/internal/infra/database/user"
+	"fullcycle-auction_go/internal/infra/webhook"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
 	"fullcycle-auction_go/internal/usecase/user_usecase"
@@ -16,6 +19,8 @@ import (
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/mongo"
 	"log"
+	"net/http"
+	"os"
 )
 
 func main() {
@@ -34,7 +39,11 @@ func main() {
 
 	router := gin.Default()
 
-	userController, bidController, auctionsController := initDependencies(databaseConnection)
+	userController, bidController, auctionsController, auctionRepository := initDependencies(databaseConnection)
+	if err := auctionRepository.EnsureIndexes(ctx); err != nil {
+		log.Fatal(err.Error())
+		return
+	}
 
 	router.GET("/auction", auctionsController.FindAuctions)
 	router.GET("/auction/:auctionId", auctionsController.FindAuctionById)
@@ -43,6 +52,20 @@ func main() {
 	router.POST("/bid", bidController.CreateBid)
 	router.GET("/bid/:auctionId", bidController.FindBidByAuctionId)
 	router.GET("/user/:userId", userController.FindUserById)
+	router.GET("/metrics", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4")
+		if err := auctionRepository.WriteMetrics(c.Writer); err != nil {
+			c.Status(http.StatusInternalServerError)
+		}
+	})
+	router.GET("/health", func(c *gin.Context) {
+		health := auctionRepository.Health()
+		if !health.Healthy() {
+			c.JSON(http.StatusServiceUnavailable, health)
+			return
+		}
+		c.JSON(http.StatusOK, health)
+	})
 
 	router.Run(":8080")
 }
@@ -50,9 +73,18 @@ func main() {
 func initDependencies(database *mongo.Database) (
 	userController *user_controller.UserController,
 	bidController *bid_controller.BidController,
-	auctionController *auction_controller.AuctionController) {
+	auctionController *auction_controller.AuctionController,
+	auctionRepository *auction.AuctionRepository) {
 
-	auctionRepository := auction.NewAuctionRepository(database)
+	auctionOptions := []auction.Option{auction.WithMetrics(metrics.NewPrometheusAuctionMetrics())}
+	sender, err := webhook.NewSenderFromEnv()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	if sender != nil {
+		auctionOptions = append(auctionOptions, auction.WithOnAuctionClosed(auction.NotifyInBackground(sender)))
+	}
+	auctionRepository = auction.NewAuctionRepository(database, auctionOptions...)
 	bidRepository := bid.NewBidRepository(database, auctionRepository)
 	userRepository := user.NewUserRepository(database)
 
@@ -60,7 +92,12 @@ func initDependencies(database *mongo.Database) (
 		user_usecase.NewUserUseCase(userRepository))
 	auctionController = auction_controller.NewAuctionController(
 		auction_usecase.NewAuctionUseCase(auctionRepository, bidRepository))
-	bidController = bid_controller.NewBidController(bid_usecase.NewBidUseCase(bidRepository))
+	// BID_REQUIRE_VERIFIED_USERS=true aceita lances apenas de usuários verificados
+	var userVerifier user_entity.UserVerifier
+	if os.Getenv("BID_REQUIRE_VERIFIED_USERS") == "true" {
+		userVerifier = userRepository
+	}
+	bidController = bid_controller.NewBidController(bid_usecase.NewBidUseCase(bidRepository, userVerifier))
 
 	return
 }
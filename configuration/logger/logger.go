@@ -26,13 +26,63 @@ func init() {
 	log, _ = logConfiguration.Build()
 }
 
+// Replace troca o logger do pacote e retorna uma função que restaura o
+// anterior; permite inspecionar os campos registrados em testes
+func Replace(logger *zap.Logger) (restore func()) {
+	previous := log
+	log = logger
+	return func() { log = previous }
+}
+
 func Info(message string, tags ...zap.Field) {
 	log.Info(message, tags...)
 	log.Sync()
 }
 
+func Warn(message string, tags ...zap.Field) {
+	log.Warn(message, tags...)
+	log.Sync()
+}
+
 func Error(message string, err error, tags ...zap.Field) {
 	tags = append(tags, zap.NamedError("error", err))
 	log.Error(message, tags...)
 	log.Sync()
 }
+
+// Logger registra as mensagens em um *zap.Logger próprio, permitindo
+// injetar um logger com escopo (ex.: por tenant ou em testes). Um Logger
+// nil ou sem zap.Logger usa o logger global do pacote
+type Logger struct {
+	log *zap.Logger
+}
+
+func New(log *zap.Logger) *Logger {
+	return &Logger{log: log}
+}
+
+func (l *Logger) target() *zap.Logger {
+	if l == nil || l.log == nil {
+		return log
+	}
+	return l.log
+}
+
+func (l *Logger) Info(message string, tags ...zap.Field) {
+	target := l.target()
+	target.Info(message, tags...)
+	target.Sync()
+}
+
+func (l *Logger) Warn(message string, tags ...zap.Field) {
+	target := l.target()
+	target.Warn(message, tags...)
+	target.Sync()
+}
+
+func (l *Logger) Error(message string, err error, tags ...zap.Field) {
+	tags = append(tags, zap.NamedError("error", err))
+	target := l.target()
+	target.Error(message, tags...)
+	target.Sync()
+}
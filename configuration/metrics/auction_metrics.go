@@ -0,0 +1,58 @@
+package metrics
+
+import "io"
+
+// AuctionMetrics recebe os eventos de criação e fechamento de leilões
+type AuctionMetrics interface {
+	IncAuctionsCreated()
+	IncAuctionsClosed()
+	SetActiveAuctions(count int)
+}
+
+// NoopAuctionMetrics descarta as métricas; é o padrão do repositório
+type NoopAuctionMetrics struct{}
+
+func (NoopAuctionMetrics) IncAuctionsCreated()   {}
+func (NoopAuctionMetrics) IncAuctionsClosed()    {}
+func (NoopAuctionMetrics) SetActiveAuctions(int) {}
+
+// PrometheusAuctionMetrics acumula as métricas de leilões para exportação
+// no formato texto do Prometheus
+type PrometheusAuctionMetrics struct {
+	Created *Counter
+	Closed  *Counter
+	Active  *Gauge
+}
+
+func NewPrometheusAuctionMetrics() *PrometheusAuctionMetrics {
+	return &PrometheusAuctionMetrics{
+		Created: NewCounter("auctions_created_total", "Number of auctions created by this instance"),
+		Closed:  NewCounter("auctions_closed_total", "Number of auctions closed automatically by this instance"),
+		Active:  NewGauge("active_auctions", "Number of active auctions tracked by this instance"),
+	}
+}
+
+func (m *PrometheusAuctionMetrics) IncAuctionsCreated() {
+	m.Created.Inc()
+}
+
+func (m *PrometheusAuctionMetrics) IncAuctionsClosed() {
+	m.Closed.Inc()
+}
+
+func (m *PrometheusAuctionMetrics) SetActiveAuctions(count int) {
+	m.Active.Set(float64(count))
+}
+
+func (m *PrometheusAuctionMetrics) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for _, metric := range []io.WriterTo{m.Created, m.Closed, m.Active} {
+		n, err := metric.WriteTo(w)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrometheusAuctionMetricsWrite(t *testing.T) {
+	auctionMetrics := NewPrometheusAuctionMetrics()
+
+	auctionMetrics.IncAuctionsCreated()
+	auctionMetrics.IncAuctionsCreated()
+	auctionMetrics.IncAuctionsClosed()
+	auctionMetrics.SetActiveAuctions(1)
+
+	var builder strings.Builder
+	if _, err := auctionMetrics.WriteTo(&builder); err != nil {
+		t.Fatalf("Failed to write metrics: %v", err)
+	}
+
+	for _, expected := range []string{
+		"# TYPE auctions_created_total counter\nauctions_created_total 2",
+		"# TYPE auctions_closed_total counter\nauctions_closed_total 1",
+		"# TYPE active_auctions gauge\nactive_auctions 1",
+	} {
+		if !strings.Contains(builder.String(), expected) {
+			t.Errorf("Expected output to contain %q, got %q", expected, builder.String())
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Counter é um contador sem labels exportado no formato texto do Prometheus
+type Counter struct {
+	name  string
+	help  string
+	value float64
+	mutex sync.Mutex
+}
+
+func NewCounter(name, help string) *Counter {
+	return &Counter{name: name, help: help}
+}
+
+func (c *Counter) Inc() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.value++
+}
+
+func (c *Counter) Value() float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.value
+}
+
+func (c *Counter) WriteTo(w io.Writer) (int64, error) {
+	return writeSample(w, c.name, c.help, "counter", c.Value())
+}
+
+// Gauge é um gauge sem labels exportado no formato texto do Prometheus
+type Gauge struct {
+	name  string
+	help  string
+	value float64
+	mutex sync.Mutex
+}
+
+func NewGauge(name, help string) *Gauge {
+	return &Gauge{name: name, help: help}
+}
+
+func (g *Gauge) Set(value float64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.value = value
+}
+
+func (g *Gauge) Value() float64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.value
+}
+
+func (g *Gauge) WriteTo(w io.Writer) (int64, error) {
+	return writeSample(w, g.name, g.help, "gauge", g.Value())
+}
+
+func writeSample(w io.Writer, name, help, metricType string, value float64) (int64, error) {
+	n, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, metricType, name, value)
+	return int64(n), err
+}
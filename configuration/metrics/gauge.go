@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GaugeVec é um gauge com labels exportado no formato texto do Prometheus
+type GaugeVec struct {
+	name       string
+	help       string
+	labelNames []string
+
+	values map[string]float64
+	labels map[string][]string
+	mutex  sync.Mutex
+}
+
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     make(map[string]float64),
+		labels:     make(map[string][]string),
+	}
+}
+
+func (g *GaugeVec) Add(delta float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.values[key] += delta
+	g.labels[key] = labelValues
+}
+
+func (g *GaugeVec) Value(labelValues ...string) float64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.values[strings.Join(labelValues, "\xff")]
+}
+
+func (g *GaugeVec) WriteTo(w io.Writer) (int64, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+
+	keys := make([]string, 0, len(g.values))
+	for key := range g.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		pairs := make([]string, len(g.labelNames))
+		for i, labelName := range g.labelNames {
+			pairs[i] = fmt.Sprintf("%s=%q", labelName, g.labels[key][i])
+		}
+		fmt.Fprintf(&builder, "%s{%s} %v\n", g.name, strings.Join(pairs, ","), g.values[key])
+	}
+
+	n, err := io.WriteString(w, builder.String())
+	return int64(n), err
+}
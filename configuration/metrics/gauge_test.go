@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGaugeVecAddAndWrite(t *testing.T) {
+	gauge := NewGaugeVec("auctions", "Auctions by category", "category", "status")
+
+	gauge.Add(1, "electronics", "active")
+	gauge.Add(1, "electronics", "active")
+	gauge.Add(-1, "electronics", "active")
+
+	if value := gauge.Value("electronics", "active"); value != 1 {
+		t.Errorf("Expected gauge value 1, got %v", value)
+	}
+
+	var builder strings.Builder
+	if _, err := gauge.WriteTo(&builder); err != nil {
+		t.Fatalf("Failed to write gauge: %v", err)
+	}
+
+	expected := `auctions{category="electronics",status="active"} 1`
+	if !strings.Contains(builder.String(), expected) {
+		t.Errorf("Expected output to contain %q, got %q", expected, builder.String())
+	}
+}
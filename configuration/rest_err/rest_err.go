@@ -6,10 +6,11 @@ import (
 )
 
 type RestErr struct {
-	Message string   `json:"message"`
-	Err     string   `json:"err"`
-	Code    int      `json:"code"`
-	Causes  []Causes `json:"causes"`
+	Message   string   `json:"message"`
+	Err       string   `json:"err"`
+	Code      int      `json:"code"`
+	ErrorCode string   `json:"error_code,omitempty"`
+	Causes    []Causes `json:"causes"`
 }
 
 type Causes struct {
@@ -22,14 +23,20 @@ func (r *RestErr) Error() string {
 }
 
 func ConvertError(internalError *internal_error.InternalError) *RestErr {
+	var restErr *RestErr
 	switch internalError.Err {
-	case "bad_request":
-		return NewBadRequestError(internalError.Error())
-	case "not_found":
-		return NewNotFoundError(internalError.Error())
+	case internal_error.BadRequest:
+		restErr = NewBadRequestError(internalError.Error())
+	case internal_error.NotFound:
+		restErr = NewNotFoundError(internalError.Error())
+	case internal_error.ServiceUnavailable:
+		restErr = NewServiceUnavailableError(internalError.Error())
 	default:
-		return NewInternalServerError(internalError.Error())
+		restErr = NewInternalServerError(internalError.Error())
 	}
+
+	restErr.ErrorCode = internalError.Code
+	return restErr
 }
 
 func NewBadRequestError(message string, causes ...Causes) *RestErr {
@@ -58,3 +65,12 @@ func NewNotFoundError(message string) *RestErr {
 		Causes:  nil,
 	}
 }
+
+func NewServiceUnavailableError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "service_unavailable",
+		Code:    http.StatusServiceUnavailable,
+		Causes:  nil,
+	}
+}
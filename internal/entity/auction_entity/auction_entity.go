@@ -2,22 +2,34 @@ package auction_entity
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/internal/internal_error"
 	"github.com/google/uuid"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 func CreateAuction(
-	productName, category, description string,
+	sellerId, productName, category, description string,
 	condition ProductCondition) (*Auction, *internal_error.InternalError) {
+	// Todo leilão novo precisa de um vendedor; documentos antigos podem não ter
+	if strings.TrimSpace(sellerId) == "" {
+		return nil, internal_error.NewBadRequestError("seller id is required").
+			WithCode("seller_id.empty")
+	}
+
 	auction := &Auction{
-		Id:          uuid.New().String(),
-		ProductName: productName,
-		Category:    category,
-		Description: description,
-		Condition:   condition,
-		Status:      Active,
-		Timestamp:   time.Now(),
+		Id:              uuid.New().String(),
+		SellerId:        sellerId,
+		ProductName:     productName,
+		Category:        NormalizeCategory(category, CategoryLowerCase),
+		CategoryDisplay: strings.Join(strings.Fields(category), " "),
+		Description:     description,
+		Condition:       condition,
+		Status:          Active,
+		Timestamp:       time.Now(),
 	}
 
 	if err := auction.Validate(); err != nil {
@@ -27,38 +39,187 @@ func CreateAuction(
 	return auction, nil
 }
 
+// CreateDraftAuction cria um leilão em rascunho, que só passa a correr
+// (e a ser monitorado) depois de publicado
+func CreateDraftAuction(
+	sellerId, productName, category, description string,
+	condition ProductCondition) (*Auction, *internal_error.InternalError) {
+	auction, err := CreateAuction(sellerId, productName, category, description, condition)
+	if err != nil {
+		return nil, err
+	}
+
+	auction.Status = Draft
+	return auction, nil
+}
+
+// Limites da duração personalizada de um leilão
+const (
+	MinAuctionDuration = time.Minute
+	MaxAuctionDuration = 30 * 24 * time.Hour
+)
+
+// Tamanhos máximos, em caracteres, dos campos de texto do leilão
+const (
+	MaxProductNameLength = 255
+	MaxCategoryLength    = 100
+	MaxDescriptionLength = 2000
+)
+
+// Quantidade máxima de categorias adicionais de um leilão
+const MaxCategories = 10
+
+// Valida uma categoria depois de normalizada; field prefixa a mensagem e o
+// código do erro
+func validateCategory(field, category string) *internal_error.InternalError {
+	category = NormalizeCategory(category, CategoryLowerCase)
+
+	// Verifica se a categoria não ficou vazia após a normalização
+	if category == "" {
+		return internal_error.NewBadRequestError(fmt.Sprintf("%s is empty", field)).
+			WithCode(field + ".empty")
+	}
+
+	// Verifica se a categoria tem pelo menos 3 caracteres
+	if utf8.RuneCountInString(category) <= 2 {
+		return internal_error.NewBadRequestError(fmt.Sprintf("%s too short", field)).
+			WithCode(field + ".too_short")
+	}
+
+	if utf8.RuneCountInString(category) > MaxCategoryLength {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("%s must have at most %d characters", field, MaxCategoryLength)).
+			WithCode(field + ".too_long")
+	}
+
+	return nil
+}
+
 func (au *Auction) Validate() *internal_error.InternalError {
 	// Verifica se o nome do produto tem pelo menos 2 caracteres
-	if len(au.ProductName) <= 1 {
-		return internal_error.NewBadRequestError("product name too short")
+	if utf8.RuneCountInString(au.ProductName) <= 1 {
+		return internal_error.NewBadRequestError("product name too short").
+			WithCode("product_name.too_short")
 	}
-	
-	// Verifica se a categoria tem pelo menos 3 caracteres
-	if len(au.Category) <= 2 {
-		return internal_error.NewBadRequestError("category too short")
+
+	if utf8.RuneCountInString(au.ProductName) > MaxProductNameLength {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("product name must have at most %d characters", MaxProductNameLength)).
+			WithCode("product_name.too_long")
 	}
-	
+
+	if err := validateCategory("category", au.Category); err != nil {
+		return err
+	}
+
+	if len(au.Categories) > MaxCategories {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("categories must have at most %d entries", MaxCategories)).
+			WithCode("categories.too_many")
+	}
+	for _, category := range au.Categories {
+		if err := validateCategory("categories", category); err != nil {
+			return err
+		}
+	}
+
 	// Verifica se a descrição tem pelo menos 11 caracteres
-	if len(au.Description) <= 10 {
-		return internal_error.NewBadRequestError("description too short")
+	if utf8.RuneCountInString(au.Description) <= 10 {
+		return internal_error.NewBadRequestError("description too short").
+			WithCode("description.too_short")
+	}
+
+	if utf8.RuneCountInString(au.Description) > MaxDescriptionLength {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("description must have at most %d characters", MaxDescriptionLength)).
+			WithCode("description.too_long")
 	}
-	
+
+	// Verifica se a duração personalizada, quando informada, está no intervalo aceito
+	if au.Duration != 0 {
+		if err := validateDuration(au.Duration); err != nil {
+			return err
+		}
+	}
+
+	// Verifica se o preço de reserva não é negativo
+	if au.ReservePrice < 0 {
+		return internal_error.NewBadRequestError("reserve price must not be negative").
+			WithCode("reserve_price.invalid")
+	}
+
+	// Verifica se o lance inicial não é negativo
+	if au.StartingBid < 0 {
+		return internal_error.NewBadRequestError("starting bid must not be negative").
+			WithCode("starting_bid.invalid")
+	}
+
 	// Verifica se a condição é válida
-	if au.Condition != New && au.Condition != Refurbished && au.Condition != Used {
-		return internal_error.NewBadRequestError("invalid product condition")
+	if !au.Condition.Valid() {
+		return internal_error.NewBadRequestError("invalid product condition").
+			WithCode("condition.invalid")
 	}
 
 	return nil
 }
 
 type Auction struct {
-	Id          string
-	ProductName string
-	Category    string
-	Description string
-	Condition   ProductCondition
-	Status      AuctionStatus
-	Timestamp   time.Time
+	Id string `json:"id"`
+	// Usuário que publicou o leilão; vazio em leilões anteriores ao campo
+	SellerId    string `json:"seller_id,omitempty"`
+	ProductName string `json:"product_name"`
+	Category    string `json:"category"`
+	// Forma original da categoria, para exibição
+	CategoryDisplay string           `json:"category_display,omitempty"`
+	Description     string           `json:"description"`
+	Condition       ProductCondition `json:"condition"`
+	Status          AuctionStatus    `json:"status"`
+	Timestamp       time.Time        `json:"timestamp"`
+	// Duração escolhida pelo vendedor; zero usa a duração padrão
+	// (AUCTION_INTERVAL)
+	Duration time.Duration `json:"duration,omitempty"`
+	// Término previsto, calculado pelo repositório (zero para rascunhos e
+	// leilões agendados)
+	EndTime time.Time `json:"end_time"`
+	Views   int64     `json:"views"`
+	// Template usado nas notificações de encerramento (opcional)
+	NotificationTemplateId string            `json:"notification_template_id,omitempty"`
+	NotificationMetadata   map[string]string `json:"notification_metadata,omitempty"`
+	// Leilão original quando este é uma nova publicação de um não vendido
+	RelistedFrom string `json:"relisted_from,omitempty"`
+	RelistCount  int    `json:"relist_count,omitempty"`
+	// Preço de compra imediata; zero quando o leilão não oferece a opção
+	BuyNowPrice float64 `json:"buy_now_price,omitempty"`
+	// Menor valor aceito pelo vendedor; zero quando não há preço de reserva
+	ReservePrice float64 `json:"reserve_price,omitempty"`
+	// Menor lance aceito; zero quando não há lance inicial
+	StartingBid float64 `json:"starting_bid,omitempty"`
+	// Maior lance, gravado quando o leilão é encerrado (vazio sem lances ou
+	// quando o maior lance não atinge a reserva, indicado em ReserveNotMet)
+	WinnerBidId   string `json:"winner_bid_id,omitempty"`
+	WinnerUserId  string `json:"winner_user_id,omitempty"`
+	ReserveNotMet bool   `json:"reserve_not_met,omitempty"`
+	// Valor pago pelo vencedor (maior lance ou preço de compra imediata)
+	FinalPrice float64 `json:"final_price,omitempty"`
+	// Quando o leilão foi pausado; zero quando não está pausado
+	PausedAt time.Time `json:"paused_at"`
+	// Chave enviada pelo cliente para que uma criação repetida devolva o
+	// mesmo leilão (opcional, única por vendedor)
+	IdempotencyKey string `json:"-"`
+	// Categorias adicionais (tags), normalizadas e sem repetir Category
+	Categories []string `json:"categories,omitempty"`
+	// Início agendado; zero quando o leilão começa na criação
+	StartTime time.Time `json:"start_time"`
+}
+
+// RemainingSeconds calcula o tempo restante pelo relógio do servidor, para
+// que os clientes não dependam do próprio relógio; 0 se não estiver ativo
+func (au *Auction) RemainingSeconds(now time.Time) int64 {
+	if au.Status != Active || au.EndTime.IsZero() || !au.EndTime.After(now) {
+		return 0
+	}
+
+	return int64(au.EndTime.Sub(now) / time.Second)
 }
 
 type ProductCondition int
@@ -67,6 +228,20 @@ type AuctionStatus int
 const (
 	Active AuctionStatus = iota
 	Completed
+	Draft
+	Cancelled
+	Paused
+	// Aguarda o início agendado (StartTime) para se tornar ativo
+	Scheduled
+)
+
+// Ordenação da listagem de leilões pelo horário de criação; o valor zero
+// lista os mais recentes primeiro
+type AuctionSortOrder int
+
+const (
+	SortNewestFirst AuctionSortOrder = iota
+	SortOldestFirst
 )
 
 const (
@@ -75,16 +250,289 @@ const (
 	Refurbished
 )
 
+// Valid indica se o status é um dos valores da enumeração
+func (s AuctionStatus) Valid() bool {
+	_, ok := auctionStatusNames[s]
+	return ok
+}
+
+// Valid indica se a condição é um dos valores da enumeração
+func (c ProductCondition) Valid() bool {
+	_, ok := productConditionNames[c]
+	return ok
+}
+
+// String retorna o nome do status para logs, ou Unknown(n) para valores
+// fora da enumeração
+func (s AuctionStatus) String() string {
+	if name, ok := auctionStatusNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Unknown(%d)", int(s))
+}
+
+// String retorna o nome da condição para logs, ou Unknown(n) para valores
+// fora da enumeração
+func (c ProductCondition) String() string {
+	if name, ok := productConditionNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Unknown(%d)", int(c))
+}
+
 type AuctionRepositoryInterface interface {
+	// CreateAuction retorna o leilão gravado, com o término já calculado
 	CreateAuction(
 		ctx context.Context,
-		auctionEntity *Auction) *internal_error.InternalError
+		auctionEntity *Auction) (*Auction, *internal_error.InternalError)
 
 	FindAuctions(
 		ctx context.Context,
-		status AuctionStatus,
-		category, productName string) ([]Auction, *internal_error.InternalError)
+		status *AuctionStatus,
+		categories []string,
+		productName, search string,
+		from, to time.Time,
+		sort AuctionSortOrder) ([]Auction, *internal_error.InternalError)
 
 	FindAuctionById(
 		ctx context.Context, id string) (*Auction, *internal_error.InternalError)
 }
+
+// CategoryCase define a caixa das categorias normalizadas
+type CategoryCase int
+
+const (
+	// Minúsculas (padrão)
+	CategoryLowerCase CategoryCase = iota
+	// Primeira letra de cada palavra maiúscula ("Home And Garden")
+	CategoryTitleCase
+)
+
+// ParseCategoryCase converte o valor configurado (ex.: AUCTION_CATEGORY_CASE);
+// apenas "title" usa CategoryTitleCase
+func ParseCategoryCase(value string) CategoryCase {
+	if value == "title" {
+		return CategoryTitleCase
+	}
+
+	return CategoryLowerCase
+}
+
+// NormalizeCategory remove espaços extras e padroniza a caixa da categoria
+func NormalizeCategory(category string, letterCase CategoryCase) string {
+	words := strings.Fields(category)
+
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if letterCase == CategoryTitleCase {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			word = string(runes)
+		}
+		words[i] = word
+	}
+
+	return strings.Join(words, " ")
+}
+
+// ApplyCategoryCase normaliza a categoria principal e as adicionais na caixa
+// informada; CreateAuction, SetCategories e ApplyUpdate usam minúsculas
+func (au *Auction) ApplyCategoryCase(letterCase CategoryCase) {
+	au.Category = NormalizeCategory(au.Category, letterCase)
+	for i, category := range au.Categories {
+		au.Categories[i] = NormalizeCategory(category, letterCase)
+	}
+}
+
+// DefaultNotificationTemplates são os templates aceitos quando nenhum é
+// configurado
+var DefaultNotificationTemplates = []string{"auction_closed", "auction_won", "auction_unsold"}
+
+// ParseNotificationTemplates converte a lista configurada, separada por
+// vírgulas (ex.: AUCTION_NOTIFICATION_TEMPLATES); vazia usa
+// DefaultNotificationTemplates
+func ParseNotificationTemplates(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return DefaultNotificationTemplates
+	}
+
+	var templates []string
+	for _, template := range strings.Split(value, ",") {
+		if template = strings.TrimSpace(template); template != "" {
+			templates = append(templates, template)
+		}
+	}
+	return templates
+}
+
+// SetNotificationTemplate define o template (e metadados) usado para
+// renderizar as notificações de encerramento do leilão; o template precisa
+// estar entre knownTemplates
+func (au *Auction) SetNotificationTemplate(
+	templateId string, metadata map[string]string, knownTemplates []string) *internal_error.InternalError {
+	if !isKnownNotificationTemplate(templateId, knownTemplates) {
+		return internal_error.NewBadRequestError("unknown notification template").
+			WithCode("notification_template.unknown")
+	}
+
+	au.NotificationTemplateId = templateId
+	au.NotificationMetadata = metadata
+	return nil
+}
+
+// SetBuyNowPrice define o preço que encerra o leilão imediatamente para
+// quem aceitar pagá-lo
+func (au *Auction) SetBuyNowPrice(price float64) *internal_error.InternalError {
+	if price <= 0 {
+		return internal_error.NewBadRequestError("buy now price must be greater than zero").
+			WithCode("buy_now_price.invalid")
+	}
+
+	au.BuyNowPrice = price
+	return nil
+}
+
+// SetDuration define por quanto tempo o leilão recebe lances, no lugar da
+// duração padrão
+func (au *Auction) SetDuration(duration time.Duration) *internal_error.InternalError {
+	if err := validateDuration(duration); err != nil {
+		return err
+	}
+
+	au.Duration = duration
+	return nil
+}
+
+// SetStartTime agenda o início do leilão: ele fica Scheduled, sem receber
+// lances, até start, quando o prazo começa a contar
+func (au *Auction) SetStartTime(start time.Time) *internal_error.InternalError {
+	if !start.After(au.Timestamp) {
+		return internal_error.NewBadRequestError("start time must be in the future").
+			WithCode("start_time.in_past")
+	}
+
+	au.StartTime = start
+	au.Status = Scheduled
+	return nil
+}
+
+func validateDuration(duration time.Duration) *internal_error.InternalError {
+	if duration < MinAuctionDuration || duration > MaxAuctionDuration {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("duration must be between %s and %s", MinAuctionDuration, MaxAuctionDuration)).
+			WithCode("duration.out_of_range")
+	}
+
+	return nil
+}
+
+// SetReservePrice define o menor lance capaz de vencer o leilão; zero
+// remove a reserva
+func (au *Auction) SetReservePrice(price float64) *internal_error.InternalError {
+	if price < 0 {
+		return internal_error.NewBadRequestError("reserve price must not be negative").
+			WithCode("reserve_price.invalid")
+	}
+
+	au.ReservePrice = price
+	return nil
+}
+
+// SetStartingBid define o menor lance aceito no leilão; zero remove o
+// lance inicial
+func (au *Auction) SetStartingBid(amount float64) *internal_error.InternalError {
+	if amount < 0 {
+		return internal_error.NewBadRequestError("starting bid must not be negative").
+			WithCode("starting_bid.invalid")
+	}
+
+	au.StartingBid = amount
+	return nil
+}
+
+// SetCategories define as categorias adicionais (tags) do leilão; entradas
+// repetidas ou iguais à categoria principal são descartadas
+func (au *Auction) SetCategories(categories []string) *internal_error.InternalError {
+	seen := map[string]bool{NormalizeCategory(au.Category, CategoryLowerCase): true}
+	var normalized []string
+	for _, category := range categories {
+		if err := validateCategory("categories", category); err != nil {
+			return err
+		}
+
+		category = NormalizeCategory(category, CategoryLowerCase)
+		if seen[category] {
+			continue
+		}
+		seen[category] = true
+		normalized = append(normalized, category)
+	}
+
+	if len(normalized) > MaxCategories {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("categories must have at most %d entries", MaxCategories)).
+			WithCode("categories.too_many")
+	}
+
+	au.Categories = normalized
+	return nil
+}
+
+// Campos que o vendedor pode corrigir antes do primeiro lance; nil mantém o
+// valor atual
+type AuctionUpdate struct {
+	ProductName *string
+	Category    *string
+	Description *string
+	Condition   *ProductCondition
+}
+
+// ApplyUpdate aplica os campos informados e valida o resultado; em caso de
+// erro o leilão não é alterado
+func (au *Auction) ApplyUpdate(update AuctionUpdate) *internal_error.InternalError {
+	updated := *au
+	if update.ProductName != nil {
+		updated.ProductName = *update.ProductName
+	}
+	if update.Category != nil {
+		updated.Category = NormalizeCategory(*update.Category, CategoryLowerCase)
+		updated.CategoryDisplay = strings.Join(strings.Fields(*update.Category), " ")
+	}
+	if update.Description != nil {
+		updated.Description = *update.Description
+	}
+	if update.Condition != nil {
+		updated.Condition = *update.Condition
+	}
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	*au = updated
+	return nil
+}
+
+// ValidateDescriptionWords exige ao menos minWords palavras na descrição;
+// 0 desativa a regra
+func (au *Auction) ValidateDescriptionWords(minWords int) *internal_error.InternalError {
+	if minWords > 0 && len(strings.Fields(au.Description)) < minWords {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("description must have at least %d words", minWords)).
+			WithCode("description.too_few_words")
+	}
+
+	return nil
+}
+
+func isKnownNotificationTemplate(templateId string, knownTemplates []string) bool {
+	for _, template := range knownTemplates {
+		if template == templateId {
+			return true
+		}
+	}
+
+	return false
+}
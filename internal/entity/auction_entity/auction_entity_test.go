@@ -0,0 +1,505 @@
+package auction_entity
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateErrorCodes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		auction Auction
+		code    string
+	}{
+		{
+			name: "product name too short",
+			auction: Auction{ProductName: "A", Category: "Electronics",
+				Description: "A valid description", Condition: New},
+			code: "product_name.too_short",
+		},
+		{
+			name: "category too short",
+			auction: Auction{ProductName: "Phone", Category: "El",
+				Description: "A valid description", Condition: New},
+			code: "category.too_short",
+		},
+		{
+			name: "description too short",
+			auction: Auction{ProductName: "Phone", Category: "Electronics",
+				Description: "Too short", Condition: New},
+			code: "description.too_short",
+		},
+		{
+			name: "negative reserve price",
+			auction: Auction{ProductName: "Phone", Category: "Electronics",
+				Description: "A valid description", Condition: New, ReservePrice: -1},
+			code: "reserve_price.invalid",
+		},
+		{
+			name: "negative starting bid",
+			auction: Auction{ProductName: "Phone", Category: "Electronics",
+				Description: "A valid description", Condition: New, StartingBid: -1},
+			code: "starting_bid.invalid",
+		},
+		{
+			name: "invalid condition",
+			auction: Auction{ProductName: "Phone", Category: "Electronics",
+				Description: "A valid description", Condition: 99},
+			code: "condition.invalid",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.auction.Validate()
+			if err == nil {
+				t.Fatalf("Expected validation error with code %s", tc.code)
+			}
+
+			if err.Err != "bad_request" {
+				t.Errorf("Expected bad_request error, got %s", err.Err)
+			}
+
+			if err.Code != tc.code {
+				t.Errorf("Expected error code %s, got %s", tc.code, err.Code)
+			}
+		})
+	}
+}
+
+func TestCreateAuctionRequiresSeller(t *testing.T) {
+	for _, sellerId := range []string{"", "   "} {
+		_, err := CreateAuction(sellerId, "Phone", "Electronics", "A valid description", New)
+		if err == nil || err.Code != "seller_id.empty" {
+			t.Errorf("Expected seller_id.empty for %q, got %v", sellerId, err)
+		}
+	}
+
+	auction, err := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+	if err != nil || auction.SellerId != "seller" {
+		t.Errorf("Expected the seller to be stored, got %v (%v)", auction, err)
+	}
+}
+
+func TestValidateValidAuction(t *testing.T) {
+	auction := Auction{ProductName: "Phone", Category: "Electronics",
+		Description: "A valid description", Condition: Used}
+
+	if err := auction.Validate(); err != nil {
+		t.Errorf("Expected no validation error, got %v", err)
+	}
+}
+
+func TestValidateCountsCharactersNotBytes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		auction Auction
+		code    string
+	}{
+		{
+			// "é" ocupa dois bytes, mas é um único caractere
+			name: "one accented character product name",
+			auction: Auction{ProductName: "é", Category: "Electronics",
+				Description: "A valid description", Condition: New},
+			code: "product_name.too_short",
+		},
+		{
+			name: "two character CJK category",
+			auction: Auction{ProductName: "Phone", Category: "電子",
+				Description: "A valid description", Condition: New},
+			code: "category.too_short",
+		},
+		{
+			name: "ten character accented description",
+			auction: Auction{ProductName: "Phone", Category: "Electronics",
+				Description: "ééééé ãããã", Condition: New},
+			code: "description.too_short",
+		},
+		{
+			name: "eleven character CJK description",
+			auction: Auction{ProductName: "手机", Category: "電子產品",
+				Description: "這是一個很好的二手手機", Condition: New},
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.auction.Validate()
+		if tc.code == "" {
+			if err != nil {
+				t.Errorf("%s: expected no validation error, got %v", tc.name, err)
+			}
+			continue
+		}
+
+		if err == nil || err.Code != tc.code {
+			t.Errorf("%s: expected code %s, got %v", tc.name, tc.code, err)
+		}
+	}
+}
+
+func TestValidateMaxLengths(t *testing.T) {
+	valid := func() Auction {
+		return Auction{ProductName: "Phone", Category: "electronics",
+			Description: "A valid description", Condition: New}
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(auction *Auction, length int)
+		max    int
+		code   string
+	}{
+		{"product name", func(auction *Auction, length int) {
+			auction.ProductName = strings.Repeat("é", length)
+		}, MaxProductNameLength, "product_name.too_long"},
+		{"category", func(auction *Auction, length int) {
+			auction.Category = strings.Repeat("c", length)
+		}, MaxCategoryLength, "category.too_long"},
+		{"description", func(auction *Auction, length int) {
+			auction.Description = strings.Repeat("d", length)
+		}, MaxDescriptionLength, "description.too_long"},
+	}
+
+	for _, tc := range testCases {
+		atLimit := valid()
+		tc.mutate(&atLimit, tc.max)
+		if err := atLimit.Validate(); err != nil {
+			t.Errorf("%s: expected %d characters to be accepted, got %v", tc.name, tc.max, err)
+		}
+
+		overLimit := valid()
+		tc.mutate(&overLimit, tc.max+1)
+		if err := overLimit.Validate(); err == nil || err.Code != tc.code {
+			t.Errorf("%s: expected code %s for %d characters, got %v", tc.name, tc.code, tc.max+1, err)
+		}
+	}
+}
+
+func TestNormalizeCategory(t *testing.T) {
+	inputs := []string{"Electronics", "electronics ", "  ELECTRONICS", "\telectronics\n"}
+
+	for _, input := range inputs {
+		if normalized := NormalizeCategory(input, CategoryLowerCase); normalized != "electronics" {
+			t.Errorf("Expected %q to normalize to electronics, got %q", input, normalized)
+		}
+	}
+
+	if normalized := NormalizeCategory("  home   and\tGarden ", CategoryLowerCase); normalized != "home and garden" {
+		t.Errorf("Expected whitespace to be collapsed, got %q", normalized)
+	}
+}
+
+func TestNormalizeCategoryTitleCase(t *testing.T) {
+	if normalized := NormalizeCategory(" home   AND garden", CategoryTitleCase); normalized != "Home And Garden" {
+		t.Errorf("Expected title-cased category, got %q", normalized)
+	}
+}
+
+func TestParseCategoryCase(t *testing.T) {
+	if letterCase := ParseCategoryCase("title"); letterCase != CategoryTitleCase {
+		t.Errorf("Expected title case, got %v", letterCase)
+	}
+	if letterCase := ParseCategoryCase(""); letterCase != CategoryLowerCase {
+		t.Errorf("Expected lower case by default, got %v", letterCase)
+	}
+}
+
+func TestApplyCategoryCase(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "home   and garden", "A valid description", New)
+	auction.SetCategories([]string{"Garden Tools"})
+
+	auction.ApplyCategoryCase(CategoryTitleCase)
+
+	if auction.Category != "Home And Garden" || auction.Categories[0] != "Garden Tools" {
+		t.Errorf("Expected title-cased categories, got %q and %v", auction.Category, auction.Categories)
+	}
+}
+
+func TestCreateAuctionNormalizesCategory(t *testing.T) {
+	auction, err := CreateAuction("seller", "Phone", "  Consumer   Electronics ", "A valid description", New)
+	if err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	if auction.Category != "consumer electronics" {
+		t.Errorf("Expected normalized category, got %q", auction.Category)
+	}
+
+	if auction.CategoryDisplay != "Consumer Electronics" {
+		t.Errorf("Expected display category to keep the original casing, got %q", auction.CategoryDisplay)
+	}
+}
+
+func TestCreateAuctionRejectsBlankCategory(t *testing.T) {
+	_, err := CreateAuction("seller", "Phone", "   \t ", "A valid description", New)
+	if err == nil {
+		t.Fatalf("Expected blank category to be rejected")
+	}
+
+	if err.Code != "category.empty" {
+		t.Errorf("Expected error code category.empty, got %s", err.Code)
+	}
+}
+
+func TestSetNotificationTemplate(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	if err := auction.SetNotificationTemplate("auction_won", map[string]string{"lang": "pt"}, DefaultNotificationTemplates); err != nil {
+		t.Fatalf("Expected known template to be accepted, got %v", err)
+	}
+
+	if auction.NotificationTemplateId != "auction_won" || auction.NotificationMetadata["lang"] != "pt" {
+		t.Errorf("Expected template and metadata to be set, got %+v", auction)
+	}
+
+	err := auction.SetNotificationTemplate("unknown_template", nil, DefaultNotificationTemplates)
+	if err == nil || err.Code != "notification_template.unknown" {
+		t.Errorf("Expected unknown template to be rejected, got %v", err)
+	}
+}
+
+func TestSetNotificationTemplateUsesConfiguredTemplates(t *testing.T) {
+	templates := ParseNotificationTemplates("custom_close, custom_won")
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	if err := auction.SetNotificationTemplate("custom_won", nil, templates); err != nil {
+		t.Errorf("Expected configured template to be accepted, got %v", err)
+	}
+
+	if err := auction.SetNotificationTemplate("auction_won", nil, templates); err == nil {
+		t.Errorf("Expected default template to be rejected when templates are configured")
+	}
+}
+
+func TestParseNotificationTemplatesDefaults(t *testing.T) {
+	if templates := ParseNotificationTemplates("  "); !reflect.DeepEqual(templates, DefaultNotificationTemplates) {
+		t.Errorf("Expected default templates, got %v", templates)
+	}
+}
+
+func TestValidateDescriptionWords(t *testing.T) {
+	testCases := []struct {
+		description string
+		code        string
+	}{
+		{"aaaaaaaaaaaaaaaa", "description.too_few_words"},
+		{"two   words here", ""},
+	}
+
+	for _, tc := range testCases {
+		auction := Auction{ProductName: "Phone", Category: "Electronics",
+			Description: tc.description, Condition: Used}
+
+		err := auction.ValidateDescriptionWords(3)
+		if tc.code == "" {
+			if err != nil {
+				t.Errorf("%q: expected no validation error, got %v", tc.description, err)
+			}
+			continue
+		}
+
+		if err == nil || err.Code != tc.code {
+			t.Errorf("%q: expected code %s, got %v", tc.description, tc.code, err)
+		}
+	}
+}
+
+func TestValidateDescriptionWordsDisabled(t *testing.T) {
+	auction := Auction{ProductName: "Phone", Category: "Electronics",
+		Description: "aaaaaaaaaaaaaaaa", Condition: Used}
+
+	if err := auction.ValidateDescriptionWords(0); err != nil {
+		t.Errorf("Expected single-word description to pass when the rule is off, got %v", err)
+	}
+}
+
+func TestRemainingSeconds(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		auction  Auction
+		expected int64
+	}{
+		{"active", Auction{Status: Active, EndTime: now.Add(90*time.Second + 500*time.Millisecond)}, 90},
+		{"active past end time", Auction{Status: Active, EndTime: now.Add(-time.Second)}, 0},
+		{"completed", Auction{Status: Completed, EndTime: now.Add(time.Minute)}, 0},
+		{"unknown end time", Auction{Status: Active}, 0},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.auction.RemainingSeconds(now); got != tc.expected {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestSetBuyNowPrice(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	if err := auction.SetBuyNowPrice(1500); err != nil || auction.BuyNowPrice != 1500 {
+		t.Fatalf("Expected buy now price to be set, got %v (%v)", auction.BuyNowPrice, err)
+	}
+
+	for _, price := range []float64{0, -10} {
+		if err := auction.SetBuyNowPrice(price); err == nil || err.Code != "buy_now_price.invalid" {
+			t.Errorf("Expected price %v to be rejected, got %v", price, err)
+		}
+	}
+
+	if auction.BuyNowPrice != 1500 {
+		t.Errorf("Expected rejected prices to keep the previous value, got %v", auction.BuyNowPrice)
+	}
+}
+
+func TestSetCategories(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	if err := auction.SetCategories([]string{" Gadgets ", "gadgets", "Electronics", "Mobile"}); err != nil {
+		t.Fatalf("Expected categories to be set, got %v", err)
+	}
+	if strings.Join(auction.Categories, ",") != "gadgets,mobile" {
+		t.Errorf("Expected normalized categories without repeats or the primary one, got %v", auction.Categories)
+	}
+
+	if err := auction.SetCategories([]string{"tv"}); err == nil || err.Code != "categories.too_short" {
+		t.Errorf("Expected a short category to be rejected, got %v", err)
+	}
+
+	tooMany := make([]string, MaxCategories+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("category %d", i)
+	}
+	if err := auction.SetCategories(tooMany); err == nil || err.Code != "categories.too_many" {
+		t.Errorf("Expected more than %d categories to be rejected, got %v", MaxCategories, err)
+	}
+
+	if strings.Join(auction.Categories, ",") != "gadgets,mobile" {
+		t.Errorf("Expected rejected categories to keep the previous value, got %v", auction.Categories)
+	}
+}
+
+func TestSetStartTime(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	for _, start := range []time.Time{auction.Timestamp, auction.Timestamp.Add(-time.Minute)} {
+		if err := auction.SetStartTime(start); err == nil || err.Code != "start_time.in_past" {
+			t.Errorf("Expected start time %v to be rejected, got %v", start, err)
+		}
+	}
+	if auction.Status != Active || !auction.StartTime.IsZero() {
+		t.Fatalf("Expected rejected start times to keep the auction active, got %v at %v", auction.Status, auction.StartTime)
+	}
+
+	start := auction.Timestamp.Add(time.Hour)
+	if err := auction.SetStartTime(start); err != nil {
+		t.Fatalf("Expected start time to be set, got %v", err)
+	}
+	if auction.Status != Scheduled || !auction.StartTime.Equal(start) {
+		t.Errorf("Expected auction scheduled for %v, got %v at %v", start, auction.Status, auction.StartTime)
+	}
+	if auction.RemainingSeconds(start.Add(-time.Minute)) != 0 {
+		t.Errorf("Expected no remaining time before a scheduled auction starts")
+	}
+}
+
+func TestSetReservePrice(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	if err := auction.SetReservePrice(800); err != nil || auction.ReservePrice != 800 {
+		t.Fatalf("Expected reserve price to be set, got %v (%v)", auction.ReservePrice, err)
+	}
+
+	if err := auction.SetReservePrice(-1); err == nil || err.Code != "reserve_price.invalid" {
+		t.Errorf("Expected negative reserve price to be rejected, got %v", err)
+	}
+
+	if err := auction.SetReservePrice(0); err != nil || auction.ReservePrice != 0 {
+		t.Errorf("Expected zero to remove the reserve, got %v (%v)", auction.ReservePrice, err)
+	}
+}
+
+func TestSetStartingBid(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	if err := auction.SetStartingBid(100); err != nil || auction.StartingBid != 100 {
+		t.Fatalf("Expected starting bid to be set, got %v (%v)", auction.StartingBid, err)
+	}
+
+	if err := auction.SetStartingBid(-1); err == nil || err.Code != "starting_bid.invalid" {
+		t.Errorf("Expected negative starting bid to be rejected, got %v", err)
+	}
+
+	if auction.StartingBid != 100 {
+		t.Errorf("Expected rejected starting bid to keep the previous value, got %v", auction.StartingBid)
+	}
+}
+
+func TestSetDuration(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	if err := auction.SetDuration(5 * time.Minute); err != nil || auction.Duration != 5*time.Minute {
+		t.Fatalf("Expected short custom duration to be accepted, got %v (%v)", auction.Duration, err)
+	}
+
+	for _, duration := range []time.Duration{30 * time.Second, 31 * 24 * time.Hour, -time.Hour} {
+		if err := auction.SetDuration(duration); err == nil || err.Code != "duration.out_of_range" {
+			t.Errorf("Expected duration %v to be rejected, got %v", duration, err)
+		}
+	}
+
+	auction.Duration = 40 * 24 * time.Hour
+	if err := auction.Validate(); err == nil || err.Code != "duration.out_of_range" {
+		t.Errorf("Expected Validate to reject an out of range duration, got %v", err)
+	}
+}
+
+func TestEnumString(t *testing.T) {
+	testCases := []struct {
+		value    fmt.Stringer
+		expected string
+	}{
+		{Active, "Active"},
+		{Completed, "Completed"},
+		{Draft, "Draft"},
+		{Cancelled, "Cancelled"},
+		{Paused, "Paused"},
+		{Scheduled, "Scheduled"},
+		{AuctionStatus(42), "Unknown(42)"},
+		{New, "New"},
+		{Used, "Used"},
+		{Refurbished, "Refurbished"},
+		{ProductCondition(0), "Unknown(0)"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.value.String(); got != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, got)
+		}
+	}
+}
+
+func TestApplyUpdate(t *testing.T) {
+	auction, _ := CreateAuction("seller", "Phone", "Electronics", "A valid description", New)
+
+	category := "  Mobile   Phones "
+	if err := auction.ApplyUpdate(AuctionUpdate{Category: &category}); err != nil {
+		t.Fatalf("Expected update to be applied, got %v", err)
+	}
+
+	if auction.Category != "mobile phones" || auction.CategoryDisplay != "Mobile Phones" {
+		t.Errorf("Expected normalized category, got %q (%q)", auction.Category, auction.CategoryDisplay)
+	}
+
+	description := "Too short"
+	if err := auction.ApplyUpdate(AuctionUpdate{Description: &description}); err == nil || err.Code != "description.too_short" {
+		t.Errorf("Expected invalid description to be rejected, got %v", err)
+	}
+
+	if auction.Description != "A valid description" {
+		t.Errorf("Expected rejected update to keep the previous value, got %q", auction.Description)
+	}
+}
@@ -0,0 +1,90 @@
+package auction_entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Nomes usados em String() e na serialização em JSON; o MongoDB continua
+// gravando os valores numéricos
+var auctionStatusNames = map[AuctionStatus]string{
+	Active:    "Active",
+	Completed: "Completed",
+	Draft:     "Draft",
+	Cancelled: "Cancelled",
+	Paused:    "Paused",
+	Scheduled: "Scheduled",
+}
+
+var productConditionNames = map[ProductCondition]string{
+	New:         "New",
+	Used:        "Used",
+	Refurbished: "Refurbished",
+}
+
+func (s AuctionStatus) MarshalJSON() ([]byte, error) {
+	name, ok := auctionStatusNames[s]
+	if !ok {
+		return nil, fmt.Errorf("unknown auction status %d", int(s))
+	}
+
+	return json.Marshal(name)
+}
+
+// UnmarshalJSON aceita o nome do status (sem diferenciar maiúsculas) ou,
+// por compatibilidade, o valor numérico
+func (s *AuctionStatus) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, auctionStatusNames)
+	if err != nil {
+		return fmt.Errorf("invalid auction status: %w", err)
+	}
+
+	*s = value
+	return nil
+}
+
+func (c ProductCondition) MarshalJSON() ([]byte, error) {
+	name, ok := productConditionNames[c]
+	if !ok {
+		return nil, fmt.Errorf("unknown product condition %d", int(c))
+	}
+
+	return json.Marshal(name)
+}
+
+// UnmarshalJSON aceita o nome da condição (sem diferenciar maiúsculas) ou,
+// por compatibilidade, o valor numérico
+func (c *ProductCondition) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, productConditionNames)
+	if err != nil {
+		return fmt.Errorf("invalid product condition: %w", err)
+	}
+
+	*c = value
+	return nil
+}
+
+// Converte um nome ou número JSON em um dos valores conhecidos
+func unmarshalEnum[T ~int](data []byte, names map[T]string) (T, error) {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		if _, ok := names[T(number)]; ok {
+			return T(number), nil
+		}
+		return 0, fmt.Errorf("unknown value %d", number)
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return 0, err
+	}
+
+	for value, known := range names {
+		if strings.EqualFold(known, name) {
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown value %q", name)
+}
@@ -0,0 +1,81 @@
+package auction_entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuctionJSONRoundTrip(t *testing.T) {
+	auction := Auction{
+		Id:                     "auction-id",
+		ProductName:            "Phone",
+		Category:               "electronics",
+		CategoryDisplay:        "Electronics",
+		Description:            "A valid description",
+		Condition:              Refurbished,
+		Status:                 Paused,
+		Timestamp:              time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Duration:               time.Hour,
+		EndTime:                time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+		Views:                  7,
+		NotificationTemplateId: "auction_won",
+		NotificationMetadata:   map[string]string{"locale": "pt-BR"},
+		BuyNowPrice:            1500,
+		ReservePrice:           800,
+		PausedAt:               time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(auction)
+	if err != nil {
+		t.Fatalf("Failed to marshal auction: %v", err)
+	}
+
+	for _, expected := range []string{`"product_name":"Phone"`, `"condition":"Refurbished"`, `"status":"Paused"`} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected %s in %s", expected, data)
+		}
+	}
+
+	var decoded Auction
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal auction: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, auction) {
+		t.Errorf("Expected %+v after the round trip, got %+v", auction, decoded)
+	}
+}
+
+func TestAuctionStatusAndConditionJSON(t *testing.T) {
+	var status AuctionStatus
+	for input, expected := range map[string]AuctionStatus{
+		`"Completed"`: Completed,
+		`"active"`:    Active,
+		`1`:           Completed,
+	} {
+		if err := json.Unmarshal([]byte(input), &status); err != nil || status != expected {
+			t.Errorf("For %s expected %v, got %v (%v)", input, expected, status, err)
+		}
+	}
+
+	var condition ProductCondition
+	if err := json.Unmarshal([]byte(`"used"`), &condition); err != nil || condition != Used {
+		t.Errorf("Expected Used, got %v (%v)", condition, err)
+	}
+
+	for _, input := range []string{`"Sold"`, `42`, `true`} {
+		if err := json.Unmarshal([]byte(input), &status); err == nil {
+			t.Errorf("Expected an error for status %s", input)
+		}
+		if err := json.Unmarshal([]byte(input), &condition); err == nil {
+			t.Errorf("Expected an error for condition %s", input)
+		}
+	}
+
+	if _, err := json.Marshal(AuctionStatus(42)); err == nil {
+		t.Errorf("Expected an error marshaling an unknown status")
+	}
+}
@@ -2,6 +2,7 @@ package bid_entity
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/internal/internal_error"
 	"github.com/google/uuid"
 	"time"
@@ -12,6 +13,7 @@ type Bid struct {
 	UserId    string
 	AuctionId string
 	Amount    float64
+	Currency  string
 	Timestamp time.Time
 }
 
@@ -43,14 +45,37 @@ func (b *Bid) Validate() *internal_error.InternalError {
 	return nil
 }
 
+// Maior quantidade de lances retornada em uma listagem
+const MaxFindBidsLimit = 1000
+
+// Opções de listagem de lances; Limit 0 retorna todos e a ordenação padrão
+// é do maior para o menor valor
+type FindBidsOptions struct {
+	Limit     int64
+	Ascending bool
+}
+
+func (o FindBidsOptions) Validate() *internal_error.InternalError {
+	if o.Limit < 0 || o.Limit > MaxFindBidsLimit {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("Limit must be between 0 and %d", MaxFindBidsLimit)).WithCode("limit.invalid")
+	}
+
+	return nil
+}
+
 type BidEntityRepository interface {
 	CreateBid(
 		ctx context.Context,
 		bidEntities []Bid) *internal_error.InternalError
 
 	FindBidByAuctionId(
-		ctx context.Context, auctionId string) ([]Bid, *internal_error.InternalError)
+		ctx context.Context, auctionId string, opts FindBidsOptions) ([]Bid, *internal_error.InternalError)
 
 	FindWinningBidByAuctionId(
 		ctx context.Context, auctionId string) (*Bid, *internal_error.InternalError)
+
+	// Verifica, antes de o lance entrar no lote, se ele atinge o lance
+	// inicial e supera o maior lance atual do leilão
+	CheckBidAmount(ctx context.Context, bid Bid) *internal_error.InternalError
 }
@@ -0,0 +1,45 @@
+package bid_entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateBid(t *testing.T) {
+	userId, auctionId := uuid.New().String(), uuid.New().String()
+
+	bid, err := CreateBid(userId, auctionId, 150.5)
+	if err != nil {
+		t.Fatalf("Expected bid to be created, got %v", err)
+	}
+
+	if bid.UserId != userId || bid.AuctionId != auctionId || bid.Amount != 150.5 {
+		t.Errorf("Unexpected bid %+v", bid)
+	}
+
+	if uuid.Validate(bid.Id) != nil || bid.Timestamp.IsZero() {
+		t.Errorf("Expected bid id and timestamp to be set, got %+v", bid)
+	}
+}
+
+func TestCreateBidRejectsInvalidInput(t *testing.T) {
+	validId := uuid.New().String()
+
+	testCases := []struct {
+		name              string
+		userId, auctionId string
+		amount            float64
+	}{
+		{"zero amount", validId, validId, 0},
+		{"negative amount", validId, validId, -10},
+		{"empty user id", "", validId, 10},
+		{"empty auction id", validId, "", 10},
+	}
+
+	for _, tc := range testCases {
+		if _, err := CreateBid(tc.userId, tc.auctionId, tc.amount); err == nil || err.Err != "bad_request" {
+			t.Errorf("%s: expected bad_request, got %v", tc.name, err)
+		}
+	}
+}
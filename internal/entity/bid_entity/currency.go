@@ -0,0 +1,49 @@
+package bid_entity
+
+// Converte valores entre moedas para comparar lances de moedas diferentes
+type ExchangeRateProvider interface {
+	Rate(from, to string) (float64, error)
+}
+
+// Retorna o valor do lance na moeda base. Lances sem moeda são considerados
+// na moeda base; sem provedor de câmbio, lances em outras moedas não são
+// comparáveis e ok é false
+func (b *Bid) AmountIn(baseCurrency string, rates ExchangeRateProvider) (amount float64, ok bool) {
+	if b.Currency == "" || b.Currency == baseCurrency {
+		return b.Amount, true
+	}
+
+	if rates == nil {
+		return 0, false
+	}
+
+	rate, err := rates.Rate(b.Currency, baseCurrency)
+	if err != nil || rate <= 0 {
+		return 0, false
+	}
+
+	return b.Amount * rate, true
+}
+
+// Retorna o maior lance na moeda base, ignorando os que não podem ser
+// convertidos; nil quando nenhum lance é comparável
+func HighestBid(bids []Bid, baseCurrency string, rates ExchangeRateProvider) *Bid {
+	var highest *Bid
+	var highestAmount float64
+
+	for i := range bids {
+		amount, ok := bids[i].AmountIn(baseCurrency, rates)
+		if !ok {
+			continue
+		}
+
+		// No empate vence o lance mais antigo
+		if highest == nil || amount > highestAmount ||
+			(amount == highestAmount && bids[i].Timestamp.Before(highest.Timestamp)) {
+			highest = &bids[i]
+			highestAmount = amount
+		}
+	}
+
+	return highest
+}
@@ -0,0 +1,61 @@
+package bid_entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeRates map[string]float64
+
+func (f fakeRates) Rate(from, to string) (float64, error) {
+	rate, ok := f[from+"->"+to]
+	if !ok {
+		return 0, errors.New("rate not available")
+	}
+	return rate, nil
+}
+
+func TestHighestBidConvertsCurrencies(t *testing.T) {
+	bids := []Bid{
+		{Id: "brl", Amount: 100, Currency: "BRL"},
+		{Id: "usd", Amount: 30, Currency: "USD"},
+		{Id: "eur", Amount: 10, Currency: "EUR"},
+	}
+
+	highest := HighestBid(bids, "BRL", fakeRates{"USD->BRL": 5})
+	if highest == nil || highest.Id != "usd" {
+		t.Fatalf("Expected the USD bid (150 BRL) to win, got %+v", highest)
+	}
+}
+
+func TestHighestBidTieGoesToEarliest(t *testing.T) {
+	now := time.Now()
+	bids := []Bid{
+		{Id: "later", Amount: 150, Currency: "BRL", Timestamp: now},
+		{Id: "earliest", Amount: 30, Currency: "USD", Timestamp: now.Add(-time.Minute)},
+		{Id: "lower", Amount: 100, Currency: "BRL", Timestamp: now.Add(-time.Hour)},
+	}
+
+	highest := HighestBid(bids, "BRL", fakeRates{"USD->BRL": 5})
+	if highest == nil || highest.Id != "earliest" {
+		t.Fatalf("Expected the earliest of the tied bids to win, got %+v", highest)
+	}
+}
+
+func TestHighestBidWithoutProviderComparesSameCurrencyOnly(t *testing.T) {
+	bids := []Bid{
+		{Id: "brl", Amount: 100, Currency: "BRL"},
+		{Id: "legacy", Amount: 120},
+		{Id: "usd", Amount: 1000, Currency: "USD"},
+	}
+
+	highest := HighestBid(bids, "BRL", nil)
+	if highest == nil || highest.Id != "legacy" {
+		t.Fatalf("Expected the highest same-currency bid to win, got %+v", highest)
+	}
+
+	if highest := HighestBid(bids[2:], "BRL", nil); highest != nil {
+		t.Errorf("Expected no comparable bid, got %+v", highest)
+	}
+}
@@ -6,8 +6,15 @@ import (
 )
 
 type User struct {
-	Id   string
-	Name string
+	Id       string
+	Name     string
+	Verified bool
+}
+
+// Informa se a conta do usuário foi verificada; usado para aceitar lances
+// apenas de usuários verificados
+type UserVerifier interface {
+	IsVerified(ctx context.Context, userId string) (bool, error)
 }
 
 type UserRepositoryInterface interface {
@@ -28,8 +28,9 @@ func (u *AuctionController) CreateAuction(c *gin.Context) {
 		c.JSON(restErr.Code, restErr)
 		return
 	}
+	auctionInputDTO.IdempotencyKey = c.GetHeader("Idempotency-Key")
 
-	err := u.auctionUseCase.CreateAuction(context.Background(), auctionInputDTO)
+	auctionOutputDTO, err := u.auctionUseCase.CreateAuction(context.Background(), auctionInputDTO)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
 
@@ -37,5 +38,5 @@ func (u *AuctionController) CreateAuction(c *gin.Context) {
 		return
 	}
 
-	c.Status(http.StatusCreated)
+	c.JSON(http.StatusCreated, auctionOutputDTO)
 }
@@ -2,12 +2,15 @@ package auction_controller
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/configuration/rest_err"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func (u *AuctionController) FindAuctionById(c *gin.Context) {
@@ -35,18 +38,51 @@ func (u *AuctionController) FindAuctionById(c *gin.Context) {
 
 func (u *AuctionController) FindAuctions(c *gin.Context) {
 	status := c.Query("status")
-	category := c.Query("category")
+	// Aceita tanto category=a&category=b quanto category=a,b
+	var categories []string
+	for _, value := range c.QueryArray("category") {
+		categories = append(categories, strings.Split(value, ",")...)
+	}
 	productName := c.Query("productName")
+	search := c.Query("search")
+
+	var statusFilter *auction_usecase.AuctionStatus
+	if status != "" {
+		statusNumber, errConv := strconv.Atoi(status)
+		if errConv != nil {
+			errRest := rest_err.NewBadRequestError("Error trying to validate auction status param")
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+
+		auctionStatus := auction_usecase.AuctionStatus(statusNumber)
+		statusFilter = &auctionStatus
+	}
+
+	from, errRest := parseTimeQuery(c, "from")
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+	to, errRest := parseTimeQuery(c, "to")
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
 
-	statusNumber, errConv := strconv.Atoi(status)
-	if errConv != nil {
-		errRest := rest_err.NewBadRequestError("Error trying to validate auction status param")
+	var oldestFirst bool
+	switch c.DefaultQuery("order", "desc") {
+	case "asc":
+		oldestFirst = true
+	case "desc":
+	default:
+		errRest := rest_err.NewBadRequestError("Error trying to validate order param, use asc or desc")
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
 	auctions, err := u.auctionUseCase.FindAuctions(context.Background(),
-		auction_usecase.AuctionStatus(statusNumber), category, productName)
+		statusFilter, categories, productName, search, from, to, oldestFirst)
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
@@ -56,6 +92,22 @@ func (u *AuctionController) FindAuctions(c *gin.Context) {
 	c.JSON(http.StatusOK, auctions)
 }
 
+// Lê um horário opcional (RFC 3339) da query string; ausente vira zero
+func parseTimeQuery(c *gin.Context, param string) (time.Time, *rest_err.RestErr) {
+	value := c.Query(param)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, rest_err.NewBadRequestError(
+			fmt.Sprintf("Error trying to validate %s param, use RFC 3339 (e.g. 2024-01-02T15:04:05Z)", param))
+	}
+
+	return parsed, nil
+}
+
 func (u *AuctionController) FindWinningBidByAuctionId(c *gin.Context) {
 	auctionId := c.Param("auctionId")
 
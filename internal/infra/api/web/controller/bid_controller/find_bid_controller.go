@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
+	"strconv"
 )
 
 func (u *BidController) FindBidByAuctionId(c *gin.Context) {
@@ -21,7 +22,29 @@ func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 		return
 	}
 
-	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(context.Background(), auctionId)
+	var limit int64
+	if limitParam := c.Query("limit"); limitParam != "" {
+		value, errConv := strconv.ParseInt(limitParam, 10, 64)
+		if errConv != nil {
+			errRest := rest_err.NewBadRequestError("Error trying to validate limit param")
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+		limit = value
+	}
+
+	var ascending bool
+	switch c.DefaultQuery("order", "desc") {
+	case "asc":
+		ascending = true
+	case "desc":
+	default:
+		errRest := rest_err.NewBadRequestError("Error trying to validate order param, use asc or desc")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(context.Background(), auctionId, limit, ascending)
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
@@ -16,14 +16,14 @@ import (
 // TestAuctionAutoCloseInMemory realiza um teste do fechamento automático
 // sem depender de um banco de dados externo
 func TestAuctionAutoCloseInMemory(t *testing.T) {
-	// Mock do repositório para teste
+	// Mock do repositório para teste, com relógio controlado pelo teste
 	mockRepo := setupInMemoryRepository()
-
-	// Configura tempo curto para o teste
-	os.Setenv("AUCTION_INTERVAL", "1s")
+	clock := newFakeClock(time.Now())
+	mockRepo.clock = clock
 
 	// Cria um leilão para teste
 	auction, err := auction_entity.CreateAuction(
+		"seller",
 		"Test Product",
 		"Test Category",
 		"Test Description for the product that needs to be at least 10 chars",
@@ -40,13 +40,19 @@ func TestAuctionAutoCloseInMemory(t *testing.T) {
 	}
 
 	// Registra o leilão no mapa de leilões ativos
-	endTime := time.Now().Add(1 * time.Second)
+	endTime := clock.Now().Add(1 * time.Second)
 	mockRepo.activeAuctionsMutex.Lock()
 	mockRepo.activeAuctions[auction.Id] = endTime
 	mockRepo.activeAuctionsMutex.Unlock()
 
-	// Aguarda que o leilão expire
-	time.Sleep(1500 * time.Millisecond)
+	// Antes do fim o leilão continua sendo acompanhado
+	mockRepo.checkExpiredAuctions()
+	if !mockRepo.isTracked(auction.Id) {
+		t.Fatalf("Expected auction to stay tracked before its end time")
+	}
+
+	// Avança o relógio até depois do fim do leilão
+	clock.Advance(1500 * time.Millisecond)
 
 	// Força a verificação de leilões expirados
 	mockRepo.checkExpiredAuctions()
@@ -63,28 +69,75 @@ func TestAuctionAutoCloseInMemory(t *testing.T) {
 	}
 }
 
+// fakeClock é um Clock cujo horário só muda quando o teste o avança
+type fakeClock struct {
+	mu      sync.Mutex
+	current time.Time
+}
+
+func newFakeClock(start time.Time) *fakeClock {
+	return &fakeClock{current: start}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+// Advance move o relógio para frente
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = c.current.Add(d)
+}
+
 // Configura um repositório em memória para testes
 func setupInMemoryRepository() *AuctionRepository {
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Cria um repositório com mock para testes
-	mockRepo := &AuctionRepository{
-		Collection:          nil, // Não precisa de uma coleção real para este teste
-		activeAuctions:      make(map[string]time.Time),
-		activeAuctionsMutex: &sync.RWMutex{},
-		ctx:                 ctx,
-		cancelFunc:          cancel,
-	}
+	mockRepo := NewInMemoryAuctionRepository()
 
-	// Substituímos a função updateAuctionStatus para evitar chamadas ao MongoDB
-	mockRepo.updateAuctionStatus = func(id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
-		// Simulamos a atualização sem acessar o banco de dados
-		return nil
+	// Os testes substituem updateAuctionStatus depois de criar o repositório;
+	// a atualização em lote repete a que estiver configurada no momento
+	mockRepo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		return updateEach(ctx, mockRepo.updateAuctionStatus, ids, status)
 	}
 
 	return mockRepo
 }
 
+// Conecta ao MongoDB indicado em MONGODB_TEST_URL; o teste é pulado
+// quando a variável não está definida ou o banco está indisponível
+func setupMongoDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	mongoURL := os.Getenv("MONGODB_TEST_URL")
+	if mongoURL == "" {
+		t.Skip("Skipping test that requires MongoDB; set MONGODB_TEST_URL to run it")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURL))
+	if err != nil {
+		t.Skipf("Skipping test, MongoDB unavailable: %v", err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Skipf("Skipping test, MongoDB unavailable: %v", err)
+	}
+
+	database := client.Database("auction_test")
+	_ = database.Drop(ctx)
+
+	t.Cleanup(func() {
+		_ = database.Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	})
+
+	return database
+}
+
 // TestAuctionAutoClose é um teste que requer um MongoDB local
 // Este teste será pulado por padrão
 func TestAuctionAutoClose(t *testing.T) {
@@ -114,6 +167,7 @@ func TestAuctionAutoClose(t *testing.T) {
 
 	// Cria um leilão para teste
 	auction, err := auction_entity.CreateAuction(
+		"seller",
 		"Test Product",
 		"Test Category",
 		"Test Description for the product that is long enough",
@@ -124,7 +178,7 @@ func TestAuctionAutoClose(t *testing.T) {
 	}
 
 	// Insere o leilão
-	err = repo.CreateAuction(ctx, auction)
+	_, err = repo.CreateAuction(ctx, auction)
 	if err != nil {
 		t.Fatalf("Failed to create auction: %v", err)
 	}
@@ -0,0 +1,165 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Quantos leilões expirados cada atualização em lote fecha por padrão
+const defaultBatchCloseSize = 100
+
+// Leilão fechado pela atualização em lote, com a categoria usada nas métricas
+type batchClosedAuction struct {
+	id       string
+	category string
+}
+
+// Fecha com um único UpdateMany os leilões expirados que ainda estão no
+// mapa e os remove dele. Como em transitionWith, os leilões são reservados
+// sob o lock de escrita, a atualização roda fora dele e o mapa só muda
+// depois dela; os reservados por outra transição ficam para o próximo tick.
+// Só os leilões que a atualização de fato concluiu são devolvidos em closed;
+// os que mudaram de status por outra operação (ex.: cancelados) saem do mapa
+// e voltam em skipped, sem vencedor, evento ou republicação
+func (ar *AuctionRepository) closeExpiredInBatch(
+	ctx context.Context, ids []string) (closed, skipped []batchClosedAuction, err *internal_error.InternalError) {
+	reserved := ar.reserve(ids, ar.isTrackedLocked)
+	if len(reserved) == 0 {
+		return nil, nil, nil
+	}
+
+	updated, err := ar.updateAuctionsStatus(ctx, reserved, auction_entity.Completed)
+
+	ar.activeAuctionsMutex.Lock()
+	ar.releaseLocked(reserved)
+	if err == nil {
+		closed, skipped = ar.untrackClosedLocked(reserved, updated)
+	}
+	ar.activeAuctionsMutex.Unlock()
+
+	ar.notifyAuctionsChanged(updated...)
+	return closed, skipped, err
+}
+
+// Remove do mapa os leilões reservados, separando os que a atualização
+// concluiu dos que mudaram de status por outra operação; deve ser chamada
+// com activeAuctionsMutex adquirido
+func (ar *AuctionRepository) untrackClosedLocked(
+	reserved, updated []string) (closed, skipped []batchClosedAuction) {
+	completed := make(map[string]bool, len(updated))
+	for _, id := range updated {
+		completed[id] = true
+	}
+	for _, id := range reserved {
+		category, _ := ar.untrackLocked(id)
+		if completed[id] {
+			closed = append(closed, batchClosedAuction{id: id, category: category})
+		} else {
+			skipped = append(skipped, batchClosedAuction{id: id, category: category})
+		}
+	}
+	return closed, skipped
+}
+
+// Reserva, como em transitionWith, os leilões que não estão reservados por
+// outra transição e atendem a eligible (opcional, avaliada sob o lock), e
+// os retorna
+func (ar *AuctionRepository) reserve(ids []string, eligible func(id string) bool) []string {
+	ar.activeAuctionsMutex.Lock()
+	defer ar.activeAuctionsMutex.Unlock()
+
+	var reserved []string
+	for _, id := range ids {
+		if !ar.isReservedLocked(id) && (eligible == nil || eligible(id)) {
+			ar.transitioning[id] = struct{}{}
+			reserved = append(reserved, id)
+		}
+	}
+	return reserved
+}
+
+// Libera as reservas dos leilões; deve ser chamada com activeAuctionsMutex
+// adquirido
+func (ar *AuctionRepository) releaseLocked(ids []string) {
+	for _, id := range ids {
+		delete(ar.transitioning, id)
+	}
+}
+
+// Implementação real da atualização de status em lote no banco de dados.
+// Retorna os ids que a atualização mudou: cada execução grava um
+// identificador próprio junto com o status, e, se algum leilão mudou de
+// status antes da atualização, os que ela concluiu são lidos por esse
+// identificador. Leilões concluídos por outra instância no mesmo intervalo
+// não são devolvidos
+func (ar *AuctionRepository) updateAuctionsStatusImpl(
+	ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+	ctx, cancel := context.WithTimeout(ctx, ar.opTimeout)
+	defer cancel()
+
+	updateId := uuid.New().String()
+	filter := bson.M{"_id": bson.M{"$in": ids}, "status": bson.M{"$in": statusesUpdatableTo(status)}}
+	update := bson.M{"$set": bson.M{"status": status, "status_update_id": updateId}}
+
+	result, err := ar.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return nil, ar.batchUpdateError(err, len(ids), status)
+	}
+
+	updated := ids
+	if result.ModifiedCount < int64(len(ids)) {
+		updated, err = ar.findAuctionIds(ctx, bson.M{"_id": bson.M{"$in": ids}, "status_update_id": updateId})
+		if err != nil {
+			return nil, ar.batchUpdateError(err, len(ids), status)
+		}
+
+		ar.logger.Warn("Some auctions changed status before the batch update and were left as they were",
+			zap.Int("auctions", len(ids)),
+			zap.Int("updated", len(updated)))
+	}
+
+	return updated, nil
+}
+
+// Ids dos leilões que atendem ao filtro; opts permite, por exemplo, limitar
+// a consulta
+func (ar *AuctionRepository) findAuctionIds(
+	ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]string, error) {
+	findOptions := options.MergeFindOptions(append(opts, options.Find().SetProjection(bson.M{"_id": 1}))...)
+	cursor, err := ar.Collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []struct {
+		Id string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(documents))
+	for i, document := range documents {
+		ids[i] = document.Id
+	}
+	return ids, nil
+}
+
+// Registra a falha da atualização em lote e a converte no erro devolvido
+func (ar *AuctionRepository) batchUpdateError(
+	err error, auctions int, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	ar.logger.Error("Error updating auctions status in batch", err,
+		zap.Int("auctions", auctions),
+		zap.Stringer("status", status))
+	if isConnectivityError(err) {
+		return internal_error.NewServiceUnavailableError("Database unavailable while updating auctions status")
+	}
+	return internal_error.NewInternalServerError("Error updating auctions status")
+}
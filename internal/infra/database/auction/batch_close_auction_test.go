@@ -0,0 +1,186 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+)
+
+func TestExpiredAuctionsClosedWithSingleBulkUpdate(t *testing.T) {
+	repo := setupInMemoryRepository()
+	// Mesmo orçamento do monitor em produção
+	repo.sweepBudget = getSweepBudget()
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		t.Fatalf("Expected no per-auction update when closing in batch, got one for %s", id)
+		return nil
+	}
+
+	var batches [][]string
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		if status != auction_entity.Completed {
+			t.Errorf("Expected auctions to be completed, got status %v", status)
+		}
+		batches = append(batches, ids)
+		return ids, nil
+	}
+
+	const total = 50
+	for i := 0; i < total; i++ {
+		repo.trackAuction(fmt.Sprintf("auction-%d", i), time.Now().Add(-time.Second), "books")
+	}
+	repo.trackAuction("running", time.Now().Add(time.Hour), "books")
+
+	repo.checkExpiredAuctions()
+
+	if len(batches) != 1 || len(batches[0]) != total {
+		t.Fatalf("Expected a single bulk update with %d auctions, got %d updates", total, len(batches))
+	}
+
+	if count := repo.ActiveAuctionCount(); count != 1 {
+		t.Errorf("Expected only the running auction to stay tracked, got %d", count)
+	}
+
+	if got := repo.categoryGauge.Value("books", "completed"); got != total {
+		t.Errorf("Expected completed gauge to be %d, got %v", total, got)
+	}
+}
+
+func TestBatchCloseSplitsIntoChunksWithinBudget(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.sweepBudget = 10 * time.Millisecond
+	repo.batchCloseSize = 5
+
+	var batches [][]string
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		time.Sleep(15 * time.Millisecond)
+		batches = append(batches, ids)
+		return ids, nil
+	}
+
+	const total = 12
+	for i := 0; i < total; i++ {
+		repo.trackAuction(fmt.Sprintf("auction-%d", i), time.Now().Add(-time.Second), "books")
+	}
+
+	repo.checkExpiredAuctions()
+
+	// O primeiro lote estoura o orçamento e os demais ficam para o próximo tick
+	if len(batches) != 1 || len(batches[0]) != 5 {
+		t.Fatalf("Expected a single chunk of 5 auctions in the first sweep, got %v", batches)
+	}
+	if count := repo.ActiveAuctionCount(); count != total-5 {
+		t.Errorf("Expected %d auctions to stay tracked, got %d", total-5, count)
+	}
+
+	repo.checkExpiredAuctions()
+	repo.checkExpiredAuctions()
+
+	if count := repo.ActiveAuctionCount(); count != 0 || len(batches) != 3 {
+		t.Errorf("Expected all auctions closed in 3 chunks, got %d tracked after %d chunks", count, len(batches))
+	}
+}
+
+func TestBatchCloseFinalizesOnlyCompletedAuctions(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	// "cancelled" mudou de status em outra instância antes da atualização
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		var updated []string
+		for _, id := range ids {
+			if id != "cancelled" {
+				updated = append(updated, id)
+			}
+		}
+		return updated, nil
+	}
+
+	var winnerLookups []string
+	repo.findHighestBid = func(ctx context.Context, auctionId string) (*auctionWinner, error) {
+		winnerLookups = append(winnerLookups, auctionId)
+		return nil, nil
+	}
+	var events []string
+	repo.OnAuctionClosed = func(event AuctionClosedEvent) {
+		events = append(events, event.AuctionId)
+	}
+	stubFindAuctionById(repo,
+		&auction_entity.Auction{Id: "expired", Category: "books", Status: auction_entity.Completed},
+		&auction_entity.Auction{Id: "cancelled", Category: "books", Status: auction_entity.Cancelled})
+
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+	repo.trackAuction("cancelled", time.Now().Add(-time.Second), "books")
+
+	repo.checkExpiredAuctions()
+
+	if len(winnerLookups) != 1 || winnerLookups[0] != "expired" {
+		t.Errorf("Expected only the completed auction to get a winner, got %v", winnerLookups)
+	}
+	if len(events) != 1 || events[0] != "expired" {
+		t.Errorf("Expected only the completed auction to publish a close event, got %v", events)
+	}
+	if count := repo.ActiveAuctionCount(); count != 0 {
+		t.Errorf("Expected both auctions to leave the map, got %d tracked", count)
+	}
+	if active, completed := repo.categoryGauge.Value("books", "active"),
+		repo.categoryGauge.Value("books", "completed"); active != 0 || completed != 1 {
+		t.Errorf("Expected 0 active and 1 completed auctions, got %v and %v", active, completed)
+	}
+}
+
+func TestBatchCloseFallsBackToPerAuctionUpdates(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		return nil, internal_error.NewInternalServerError("Error updating auctions status")
+	}
+
+	var closed []string
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		closed = append(closed, id)
+		return nil
+	}
+
+	repo.trackAuction("first", time.Now().Add(-time.Second), "books")
+	repo.trackAuction("second", time.Now().Add(-time.Second), "books")
+
+	repo.checkExpiredAuctions()
+
+	if len(closed) != 2 {
+		t.Errorf("Expected both auctions to be closed one by one, got %v", closed)
+	}
+
+	if count := repo.ActiveAuctionCount(); count != 0 {
+		t.Errorf("Expected no auctions to stay tracked, got %d", count)
+	}
+}
+
+func TestUpdateAuctionsStatusReturnsOnlyAuctionsItChanged(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	// "completed-elsewhere" foi concluído por outra instância antes da
+	// atualização e não pode ser finalizado de novo
+	seeds := []interface{}{
+		AuctionEntityMongo{Id: "expired", Status: auction_entity.Active, Timestamp: time.Now().Unix()},
+		AuctionEntityMongo{Id: "completed-elsewhere", Status: auction_entity.Completed, Timestamp: time.Now().Unix()},
+		AuctionEntityMongo{Id: "cancelled", Status: auction_entity.Cancelled, Timestamp: time.Now().Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seeds); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	updated, err := repo.updateAuctionsStatusImpl(ctx,
+		[]string{"expired", "completed-elsewhere", "cancelled"}, auction_entity.Completed)
+	if err != nil {
+		t.Fatalf("Expected the batch update to succeed, got %v", err)
+	}
+
+	if len(updated) != 1 || updated[0] != "expired" {
+		t.Errorf("Expected only the expired auction to be reported as updated, got %v", updated)
+	}
+}
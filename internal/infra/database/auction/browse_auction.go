@@ -0,0 +1,161 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Maior tamanho de página aceito por BrowseAuctions
+const maxBrowsePageSize = 100
+
+// Filtros da vitrine de leilões; Status nil significa "qualquer status"
+type BrowseAuctionsFilter struct {
+	Status      *auction_entity.AuctionStatus
+	Category    string
+	ProductName string
+}
+
+// Cartão resumido exibido na vitrine. Leilões ainda não têm imagem, por
+// isso o cartão não traz esse campo
+type AuctionCard struct {
+	Id           string    `json:"id"`
+	ProductName  string    `json:"product_name"`
+	Category     string    `json:"category"`
+	CurrentPrice float64   `json:"current_price"`
+	EndTime      time.Time `json:"end_time"`
+	// Segundos restantes pelo relógio do servidor (0 se não estiver ativo)
+	RemainingSeconds int64 `json:"remaining_seconds"`
+}
+
+type AuctionCardPage struct {
+	Cards []AuctionCard `json:"cards"`
+	Total int64         `json:"total"`
+}
+
+type auctionCardMongo struct {
+	Id              string                       `bson:"_id"`
+	ProductName     string                       `bson:"product_name"`
+	Category        string                       `bson:"category"`
+	CategoryDisplay string                       `bson:"category_display"`
+	Status          auction_entity.AuctionStatus `bson:"status"`
+	Timestamp       int64                        `bson:"timestamp"`
+	EndTime         int64                        `bson:"end_time"`
+	CurrentPrice    float64                      `bson:"current_price"`
+}
+
+// BrowseAuctions retorna uma página de cartões com o maior lance de cada
+// leilão e o total de leilões que atendem aos filtros, em uma única consulta
+func (ar *AuctionRepository) BrowseAuctions(
+	ctx context.Context,
+	filter BrowseAuctionsFilter,
+	page, pageSize int64) (*AuctionCardPage, *internal_error.InternalError) {
+	if page < 1 {
+		return nil, internal_error.NewBadRequestError("page must be at least 1").WithCode("page.invalid")
+	}
+	if pageSize < 1 || pageSize > maxBrowsePageSize {
+		return nil, internal_error.NewBadRequestError(
+			fmt.Sprintf("page size must be between 1 and %d", maxBrowsePageSize)).WithCode("page_size.invalid")
+	}
+
+	cursor, err := ar.Collection.Aggregate(ctx, browseAuctionsPipeline(
+		buildFindAuctionsFilter(filter.Status, []string{filter.Category}, filter.ProductName, "", ar.categoryCase), page, pageSize))
+	if err != nil {
+		ar.logger.Error("Error trying to browse auctions", err)
+		return nil, internal_error.NewInternalServerError("Error trying to browse auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Cards []auctionCardMongo `bson:"cards"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		ar.logger.Error("Error trying to decode browsed auctions", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode browsed auctions")
+	}
+
+	result := &AuctionCardPage{Cards: []AuctionCard{}}
+	if len(results) == 0 {
+		return result, nil
+	}
+
+	now, duration := ar.now(), ar.AuctionDuration()
+	for _, card := range results[0].Cards {
+		result.Cards = append(result.Cards, card.toCard(now, duration))
+	}
+	if len(results[0].Total) > 0 {
+		result.Total = results[0].Total[0].Count
+	}
+
+	return result, nil
+}
+
+func (cm auctionCardMongo) toCard(now time.Time, defaultDuration time.Duration) AuctionCard {
+	category := cm.CategoryDisplay
+	if category == "" {
+		category = cm.Category
+	}
+
+	auctionEntity := &auction_entity.Auction{Status: cm.Status, Timestamp: time.Unix(cm.Timestamp, 0)}
+	if cm.EndTime != 0 {
+		auctionEntity.EndTime = time.Unix(cm.EndTime, 0)
+	}
+	auctionEntity.EndTime = auctionEndTime(auctionEntity, defaultDuration)
+
+	return AuctionCard{
+		Id:               cm.Id,
+		ProductName:      cm.ProductName,
+		Category:         category,
+		CurrentPrice:     cm.CurrentPrice,
+		EndTime:          auctionEntity.EndTime,
+		RemainingSeconds: auctionEntity.RemainingSeconds(now),
+	}
+}
+
+// Ordena do mais recente para o mais antigo, pagina e busca apenas o maior
+// lance dos leilões da página; o total é contado no mesmo $facet
+func browseAuctionsPipeline(filter bson.M, page, pageSize int64) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$facet", Value: bson.M{
+			"cards": mongo.Pipeline{
+				{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: 1}}}},
+				{{Key: "$skip", Value: (page - 1) * pageSize}},
+				{{Key: "$limit", Value: pageSize}},
+				{{Key: "$lookup", Value: bson.M{
+					"from": "bids",
+					"let":  bson.M{"auctionId": "$_id"},
+					"pipeline": mongo.Pipeline{
+						{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$auction_id", "$$auctionId"}}}}},
+						// Lances em outras moedas são comparados pelo valor na moeda
+						// base; lances antigos sem base_amount usam o valor original
+						{{Key: "$addFields", Value: bson.M{"base_amount": bson.M{"$ifNull": bson.A{"$base_amount", "$amount"}}}}},
+						{{Key: "$sort", Value: bson.D{{Key: "base_amount", Value: -1}}}},
+						{{Key: "$limit", Value: 1}},
+					},
+					"as": "highest_bid",
+				}}},
+				{{Key: "$project", Value: bson.M{
+					"product_name":     1,
+					"category":         1,
+					"category_display": 1,
+					"status":           1,
+					"timestamp":        1,
+					"end_time":         1,
+					"current_price":    bson.M{"$ifNull": bson.A{bson.M{"$first": "$highest_bid.base_amount"}, 0}},
+				}}},
+			},
+			"total": mongo.Pipeline{
+				{{Key: "$count", Value: "count"}},
+			},
+		}}},
+	}
+}
@@ -0,0 +1,123 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestBrowseAuctionsRejectsInvalidPagination(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	testCases := []struct {
+		page, pageSize int64
+		code           string
+	}{
+		{0, 10, "page.invalid"},
+		{1, 0, "page_size.invalid"},
+		{1, maxBrowsePageSize + 1, "page_size.invalid"},
+	}
+
+	for _, tc := range testCases {
+		_, err := repo.BrowseAuctions(context.Background(), BrowseAuctionsFilter{}, tc.page, tc.pageSize)
+		if err == nil || err.Code != tc.code {
+			t.Errorf("page=%d size=%d: expected %s, got %v", tc.page, tc.pageSize, tc.code, err)
+		}
+	}
+}
+
+func TestBrowseAuctionsPipelinePagination(t *testing.T) {
+	pipeline := browseAuctionsPipeline(bson.M{}, 3, 20)
+
+	cards := pipeline[1][0].Value.(bson.M)["cards"].(mongo.Pipeline)
+	if skip := cards[1][0].Value; skip != int64(40) {
+		t.Errorf("Expected $skip 40 for page 3, got %v", skip)
+	}
+	if limit := cards[2][0].Value; limit != int64(20) {
+		t.Errorf("Expected $limit 20, got %v", limit)
+	}
+}
+
+func TestAuctionCardUsesDisplayCategoryAndEndTime(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+	card := auctionCardMongo{Id: "a", Category: "home office", CategoryDisplay: "Home Office",
+		Timestamp: created.Unix(), CurrentPrice: 42}.toCard(created.Add(15*time.Second), time.Minute)
+
+	if card.Category != "Home Office" {
+		t.Errorf("Expected display category, got %s", card.Category)
+	}
+	if !card.EndTime.Equal(created.Add(time.Minute)) {
+		t.Errorf("Expected end time %v, got %v", created.Add(time.Minute), card.EndTime)
+	}
+	if card.RemainingSeconds != 45 {
+		t.Errorf("Expected 45 remaining seconds, got %d", card.RemainingSeconds)
+	}
+
+	completed := auctionCardMongo{Id: "b", Status: auction_entity.Completed,
+		Timestamp: created.Unix()}.toCard(created.Add(15*time.Second), time.Minute)
+	if completed.RemainingSeconds != 0 {
+		t.Errorf("Expected 0 remaining seconds for a completed auction, got %d", completed.RemainingSeconds)
+	}
+}
+
+func TestBrowseAuctions(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	now := time.Now()
+	auctions := []interface{}{
+		AuctionEntityMongo{Id: "newest", ProductName: "Phone", Category: "electronics", Timestamp: now.Unix()},
+		AuctionEntityMongo{Id: "middle", ProductName: "Laptop", Category: "electronics", Timestamp: now.Add(-time.Minute).Unix()},
+		AuctionEntityMongo{Id: "oldest", ProductName: "Book", Category: "books", Timestamp: now.Add(-2 * time.Minute).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	bids := []interface{}{
+		bson.M{"_id": "b1", "auction_id": "middle", "amount": 100.0},
+		bson.M{"_id": "b2", "auction_id": "middle", "amount": 250.0},
+		bson.M{"_id": "b3", "auction_id": "newest", "amount": 10.0},
+		// 60 USD valem 300 na moeda base e superam o lance de 250
+		bson.M{"_id": "b4", "auction_id": "middle", "amount": 60.0, "currency": "USD", "base_amount": 300.0},
+	}
+	if _, err := database.Collection("bids").InsertMany(ctx, bids); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	firstPage, err := repo.BrowseAuctions(ctx, BrowseAuctionsFilter{}, 1, 2)
+	if err != nil {
+		t.Fatalf("Expected a page, got %v", err)
+	}
+
+	if firstPage.Total != 3 || len(firstPage.Cards) != 2 {
+		t.Fatalf("Expected 2 of 3 cards, got %d of %d", len(firstPage.Cards), firstPage.Total)
+	}
+	if firstPage.Cards[0].Id != "newest" || firstPage.Cards[1].CurrentPrice != 300 {
+		t.Errorf("Unexpected first page %+v", firstPage.Cards)
+	}
+
+	secondPage, err := repo.BrowseAuctions(ctx, BrowseAuctionsFilter{}, 2, 2)
+	if err != nil {
+		t.Fatalf("Expected a page, got %v", err)
+	}
+	if len(secondPage.Cards) != 1 || secondPage.Cards[0].Id != "oldest" || secondPage.Cards[0].CurrentPrice != 0 {
+		t.Errorf("Unexpected second page %+v", secondPage.Cards)
+	}
+
+	active := auction_entity.Active
+	filtered, err := repo.BrowseAuctions(ctx, BrowseAuctionsFilter{Status: &active, Category: "Books"}, 1, 10)
+	if err != nil {
+		t.Fatalf("Expected a page, got %v", err)
+	}
+	if filtered.Total != 1 {
+		t.Errorf("Expected 1 auction in books, got %d", filtered.Total)
+	}
+}
@@ -0,0 +1,103 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// BuyNow encerra um leilão ativo pelo preço de compra imediata, tendo
+// userId como vencedor. Status e vencedor são gravados numa única escrita,
+// dentro da mesma transição do monitor, então o leilão não pode ser
+// comprado e fechado (ou cancelado) ao mesmo tempo
+func (ar *AuctionRepository) BuyNow(ctx context.Context, auctionId, userId string) *internal_error.InternalError {
+	auctionEntity, err := ar.findAuctionById(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	if auctionEntity.BuyNowPrice <= 0 {
+		return internal_error.NewBadRequestError("auction has no buy now price").
+			WithCode("buy_now.unavailable")
+	}
+
+	if err := ar.closeWithWinner(ctx, auctionEntity, bson.M{
+		"winner_user_id": userId,
+		"final_price":    auctionEntity.BuyNowPrice,
+	}); err != nil {
+		return err
+	}
+
+	ar.logger.Info("Auction bought now",
+		zap.String("auction_id", auctionId),
+		zap.String("user_id", userId),
+		zap.Float64("price", auctionEntity.BuyNowPrice))
+	ar.publishAuctionClosed(auctionId)
+
+	return nil
+}
+
+// Conclui um leilão ativo gravando status e vencedor (winner) numa única
+// escrita, dentro da mesma transição do monitor. Recusa com
+// auction.not_active o leilão que não está ativo, já passou do fim e da
+// tolerância ou foi fechado por outra operação
+func (ar *AuctionRepository) closeWithWinner(
+	ctx context.Context, auctionEntity *auction_entity.Auction, winner bson.M) *internal_error.InternalError {
+	auctionId := auctionEntity.Id
+	closed := internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s is not active", auctionId)).WithCode("auction.not_active")
+
+	if auctionEntity.Status != auction_entity.Active {
+		return closed
+	}
+
+	// Depois do fim (e da tolerância) a compra é recusada como um lance
+	if ar.now().After(ar.auctionEndTime(auctionEntity).Add(ar.gracePeriod)) {
+		return closed
+	}
+
+	category := auctionEntity.Category
+	var wasTracked bool
+	applied, err := ar.transitionWith(ctx, auctionId,
+		func() bool {
+			// Fora do mapa o leilão já foi fechado ou está pausado; na
+			// varredura pelo banco o mapa não é usado
+			return ar.sweepStrategy == SweepDatabase || ar.isTrackedLocked(auctionId)
+		},
+		func(ctx context.Context) (bool, *internal_error.InternalError) {
+			filter := bson.M{"_id": auctionId, "status": auction_entity.Active}
+			fields := bson.M{"status": auction_entity.Completed}
+			for key, value := range winner {
+				fields[key] = value
+			}
+			matched, updateErr := ar.updateAuction(ctx, filter, bson.M{"$set": fields})
+			if updateErr != nil {
+				ar.logger.Error("Error trying to record the auction buyer", updateErr,
+					zap.String("auction_id", auctionId))
+				return false, internal_error.NewInternalServerError("Error trying to record the auction buyer")
+			}
+			return matched > 0, nil
+		},
+		func() {
+			if trackedCategory, tracked := ar.untrackLocked(auctionId); tracked {
+				category, wasTracked = trackedCategory, true
+			}
+		})
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return closed
+	}
+
+	if wasTracked {
+		ar.recordCategoryMetric(category, auction_entity.Active, -1)
+	}
+	ar.recordCategoryMetric(category, auction_entity.Completed, 1)
+
+	return nil
+}
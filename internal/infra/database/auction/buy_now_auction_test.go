@@ -0,0 +1,107 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuyNowClosesAuctionForBuyer(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo, &auction_entity.Auction{
+		Id: "auction", Category: "books", Status: auction_entity.Active, BuyNowPrice: 500,
+		EndTime: time.Now().Add(time.Hour)})
+
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		t.Errorf("Expected the status to be written together with the buyer, got a separate %v update", status)
+		return nil
+	}
+
+	var updates []bson.M
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		if expected := (bson.M{"_id": "auction", "status": auction_entity.Active}); !reflect.DeepEqual(filter, expected) {
+			t.Errorf("Expected the purchase to require an active auction, got filter %v", filter)
+		}
+		updates = append(updates, update)
+		return 1, nil
+	}
+
+	repo.trackAuction("auction", time.Now().Add(time.Hour), "books")
+
+	if err := repo.BuyNow(context.Background(), "auction", "buyer"); err != nil {
+		t.Fatalf("Expected auction to be bought, got %v", err)
+	}
+
+	if repo.isTracked("auction") {
+		t.Errorf("Expected bought auction to leave active tracking")
+	}
+
+	expected := bson.M{"$set": bson.M{
+		"status": auction_entity.Completed, "winner_user_id": "buyer", "final_price": 500.0}}
+	if len(updates) != 1 || !reflect.DeepEqual(updates[0], expected) {
+		t.Errorf("Expected a single update completing the auction for the buyer, got %v", updates)
+	}
+
+	if got := repo.categoryGauge.Value("books", "completed"); got != 1 {
+		t.Errorf("Expected completed gauge at 1, got %v", got)
+	}
+}
+
+func TestBuyNowRejections(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo,
+		&auction_entity.Auction{Id: "no-price", Status: auction_entity.Active},
+		&auction_entity.Auction{Id: "completed", Status: auction_entity.Completed, BuyNowPrice: 500},
+		&auction_entity.Auction{Id: "closed-by-monitor", Status: auction_entity.Active, BuyNowPrice: 500,
+			EndTime: time.Now().Add(time.Hour)},
+		// Terminou, mas o monitor ainda não o fechou
+		&auction_entity.Auction{Id: "ended", Status: auction_entity.Active, BuyNowPrice: 500,
+			EndTime: time.Now().Add(-time.Minute)})
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		// O leilão foi fechado pelo monitor entre a leitura e a escrita
+		if filter["_id"] == "closed-by-monitor" {
+			return 0, nil
+		}
+		t.Fatalf("Expected no winner to be recorded for a rejected purchase")
+		return 0, nil
+	}
+	repo.trackAuction("closed-by-monitor", time.Now().Add(time.Hour), "books")
+	repo.trackAuction("no-price", time.Now().Add(time.Hour), "books")
+	repo.trackAuction("ended", time.Now().Add(-time.Minute), "books")
+
+	testCases := []struct {
+		id   string
+		code string
+	}{
+		{"no-price", "buy_now.unavailable"},
+		{"completed", "auction.not_active"},
+		{"closed-by-monitor", "auction.not_active"},
+		{"ended", "auction.not_active"},
+	}
+
+	for _, tc := range testCases {
+		if err := repo.BuyNow(context.Background(), tc.id, "buyer"); err == nil || err.Code != tc.code {
+			t.Errorf("%s: expected code %s, got %v", tc.id, tc.code, err)
+		}
+	}
+
+	if !repo.isTracked("no-price") {
+		t.Errorf("Expected rejected purchase to keep the auction tracked")
+	}
+
+	// Dentro da tolerância a compra ainda é aceita
+	repo.gracePeriod = 2 * time.Minute
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) { return 1, nil }
+	if err := repo.BuyNow(context.Background(), "ended", "buyer"); err != nil {
+		t.Errorf("Expected purchase within the grace period to succeed, got %v", err)
+	}
+
+	if err := repo.BuyNow(context.Background(), "missing", "buyer"); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.uber.org/zap"
+)
+
+// CancelAuction encerra um leilão antes do prazo com o status Cancelled e o
+// remove do monitoramento. A mudança usa a mesma transição do monitor, então
+// um leilão não pode ser cancelado e concluído ao mesmo tempo
+func (ar *AuctionRepository) CancelAuction(ctx context.Context, id string) *internal_error.InternalError {
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	notFound := internal_error.NewNotFoundError(
+		fmt.Sprintf("Auction %s not found or already closed", id))
+
+	if auctionEntity.Status == auction_entity.Completed || auctionEntity.Status == auction_entity.Cancelled {
+		return notFound
+	}
+
+	category := auctionEntity.Category
+	var wasTracked bool
+	applied, err := ar.transition(ctx, id, auction_entity.Cancelled,
+		func() bool {
+			// Um leilão ativo que saiu do mapa já foi fechado pelo monitor;
+			// na varredura pelo banco o mapa não é usado
+			if auctionEntity.Status != auction_entity.Active || ar.sweepStrategy == SweepDatabase {
+				return true
+			}
+			return ar.isTrackedLocked(id)
+		},
+		func() {
+			if trackedCategory, tracked := ar.untrackLocked(id); tracked {
+				category, wasTracked = trackedCategory, true
+			}
+		})
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return notFound
+	}
+
+	if wasTracked {
+		ar.recordCategoryMetric(category, auction_entity.Active, -1)
+	}
+	if auctionEntity.Status == auction_entity.Paused {
+		ar.recordCategoryMetric(category, auction_entity.Paused, -1)
+	}
+	ar.recordCategoryMetric(category, auction_entity.Cancelled, 1)
+
+	ar.logger.Info("Auction cancelled",
+		zap.String("auction_id", id),
+		zap.Stringer("status", auction_entity.Cancelled))
+	return nil
+}
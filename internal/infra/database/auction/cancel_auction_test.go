@@ -0,0 +1,178 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCancelAuctionStopsTracking(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo, &auction_entity.Auction{
+		Id: "auction", Category: "books", Status: auction_entity.Active})
+
+	var statuses []auction_entity.AuctionStatus
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		statuses = append(statuses, status)
+		return nil
+	}
+
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.clock = ClockFunc(func() time.Time { return clock })
+	repo.trackAuction("auction", clock.Add(time.Minute), "books")
+
+	if err := repo.CancelAuction(context.Background(), "auction"); err != nil {
+		t.Fatalf("Expected auction to be cancelled, got %v", err)
+	}
+
+	if repo.isTracked("auction") {
+		t.Fatalf("Expected cancelled auction to leave active tracking")
+	}
+
+	// O prazo original passa e o monitor não deve concluir o leilão
+	clock = clock.Add(time.Hour)
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if len(statuses) != 1 || statuses[0] != auction_entity.Cancelled {
+		t.Errorf("Expected a single Cancelled update, got %v", statuses)
+	}
+
+	if got := repo.categoryGauge.Value("books", "active"); got != 0 {
+		t.Errorf("Expected active gauge at 0, got %v", got)
+	}
+	if got := repo.categoryGauge.Value("books", "cancelled"); got != 1 {
+		t.Errorf("Expected cancelled gauge at 1, got %v", got)
+	}
+}
+
+func TestCancelAuctionNotFound(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo,
+		&auction_entity.Auction{Id: "completed", Status: auction_entity.Completed},
+		&auction_entity.Auction{Id: "cancelled", Status: auction_entity.Cancelled},
+		&auction_entity.Auction{Id: "closed-by-monitor", Status: auction_entity.Active})
+
+	for _, id := range []string{"missing", "completed", "cancelled", "closed-by-monitor"} {
+		if err := repo.CancelAuction(context.Background(), id); err == nil || err.Err != "not_found" {
+			t.Errorf("%s: expected not_found, got %v", id, err)
+		}
+	}
+}
+
+func TestCancelAuctionDraft(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo, &auction_entity.Auction{Id: "draft", Status: auction_entity.Draft})
+
+	if err := repo.CancelAuction(context.Background(), "draft"); err != nil {
+		t.Errorf("Expected draft auction to be cancelled, got %v", err)
+	}
+}
+
+func TestCancelAuctionRacesMonitor(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		repo := setupInMemoryRepository()
+		stubFindAuctionById(repo, &auction_entity.Auction{Id: "auction", Status: auction_entity.Active})
+
+		var updatesMutex sync.Mutex
+		updates := 0
+		repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+			updatesMutex.Lock()
+			updates++
+			updatesMutex.Unlock()
+			return nil
+		}
+
+		clock := time.Now()
+		repo.clock = ClockFunc(func() time.Time { return clock })
+		repo.trackAuction("auction", clock.Add(-time.Second), "books")
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			repo.processExpiredAuctions(context.Background(), 0)
+		}()
+		go func() {
+			defer wg.Done()
+			repo.CancelAuction(context.Background(), "auction")
+		}()
+		wg.Wait()
+
+		if updates != 1 {
+			t.Fatalf("Expected exactly one status update, got %d", updates)
+		}
+	}
+}
+
+// Status dos leilões compartilhado por repositórios de teste, aplicando a
+// mesma regra do filtro de updateAuctionStatusImpl
+type statusStore struct {
+	mutex    sync.Mutex
+	statuses map[string]auction_entity.AuctionStatus
+}
+
+func (s *statusStore) update(id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, allowed := range statusesUpdatableTo(status) {
+		if s.statuses[id] == allowed {
+			s.statuses[id] = status
+			return nil
+		}
+	}
+	return errStatusChanged(id)
+}
+
+func TestCancelWinsOverConcurrentExpiration(t *testing.T) {
+	store := &statusStore{statuses: map[string]auction_entity.AuctionStatus{"auction": auction_entity.Active}}
+	auction := &auction_entity.Auction{Id: "auction", Category: "books", Status: auction_entity.Active}
+
+	// O monitor já coletou o leilão expirado, mas sua atualização só chega
+	// ao banco depois do cancelamento feito por outra instância
+	cancelled := make(chan struct{})
+	monitor := setupInMemoryRepository()
+	stubFindAuctionById(monitor, auction)
+	monitor.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		<-cancelled
+		return store.update(id, status)
+	}
+	monitor.trackAuction("auction", time.Now().Add(-time.Second), "books")
+
+	other := setupInMemoryRepository()
+	other.sweepStrategy = SweepDatabase
+	stubFindAuctionById(other, auction)
+	other.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return store.update(id, status)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		monitor.processExpiredAuctions(context.Background(), 0)
+	}()
+	go func() {
+		defer wg.Done()
+		defer close(cancelled)
+		if err := other.CancelAuction(context.Background(), "auction"); err != nil {
+			t.Errorf("Expected auction to be cancelled, got %v", err)
+		}
+	}()
+	wg.Wait()
+
+	if status := store.statuses["auction"]; status != auction_entity.Cancelled {
+		t.Errorf("Expected the cancellation to win, got %s", status)
+	}
+
+	if monitor.isTracked("auction") {
+		t.Errorf("Expected the skipped auction to leave active tracking")
+	}
+
+	if got := monitor.categoryGauge.Value("books", "completed"); got != 0 {
+		t.Errorf("Expected no completed auction to be recorded, got %v", got)
+	}
+}
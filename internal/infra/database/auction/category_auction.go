@@ -0,0 +1,43 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Lista as categorias distintas em uso, em ordem alfabética; status nil
+// considera leilões em qualquer status
+func (ar *AuctionRepository) ListCategories(
+	ctx context.Context,
+	status *auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+	filter := bson.M{}
+	if status != nil {
+		filter["status"] = *status
+	}
+
+	values, err := ar.Collection.Distinct(ctx, "category", filter)
+	if err != nil {
+		ar.logger.Error("Error trying to list auction categories", err)
+		return nil, internal_error.NewInternalServerError("Error trying to list auction categories")
+	}
+
+	return sortedCategories(values), nil
+}
+
+// Converte o resultado do distinct em strings ordenadas, ignorando valores
+// vazios ou de outro tipo
+func sortedCategories(values []interface{}) []string {
+	categories := make([]string, 0, len(values))
+	for _, value := range values {
+		if category, ok := value.(string); ok && category != "" {
+			categories = append(categories, category)
+		}
+	}
+
+	sort.Strings(categories)
+	return categories
+}
@@ -0,0 +1,64 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"reflect"
+	"testing"
+)
+
+func TestSortedCategories(t *testing.T) {
+	values := []interface{}{"toys", "books", "", 42, "electronics"}
+
+	expected := []string{"books", "electronics", "toys"}
+	if got := sortedCategories(values); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := sortedCategories(nil); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty, non-nil slice, got %#v", got)
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	categories, err := repo.ListCategories(ctx, nil)
+	if err != nil {
+		t.Fatalf("Expected empty collection to succeed, got %v", err)
+	}
+	if categories == nil || len(categories) != 0 {
+		t.Fatalf("Expected an empty slice for an empty collection, got %#v", categories)
+	}
+
+	seed := []interface{}{
+		AuctionEntityMongo{Id: "1", Category: "toys", Status: auction_entity.Active},
+		AuctionEntityMongo{Id: "2", Category: "books", Status: auction_entity.Active},
+		AuctionEntityMongo{Id: "3", Category: "toys", Status: auction_entity.Completed},
+		AuctionEntityMongo{Id: "4", Category: "art", Status: auction_entity.Completed},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seed); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	categories, err = repo.ListCategories(ctx, nil)
+	if err != nil {
+		t.Fatalf("Expected categories, got %v", err)
+	}
+	if expected := []string{"art", "books", "toys"}; !reflect.DeepEqual(categories, expected) {
+		t.Errorf("Expected %v, got %v", expected, categories)
+	}
+
+	active := auction_entity.Active
+	categories, err = repo.ListCategories(ctx, &active)
+	if err != nil {
+		t.Fatalf("Expected categories, got %v", err)
+	}
+	if expected := []string{"books", "toys"}; !reflect.DeepEqual(categories, expected) {
+		t.Errorf("Expected %v, got %v", expected, categories)
+	}
+}
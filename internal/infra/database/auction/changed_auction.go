@@ -0,0 +1,30 @@
+package auction
+
+// OnAuctionChanged registra uma função chamada depois de cada mudança de
+// status ou de término de um leilão (ex.: cancelamento, compra imediata,
+// pausa, prorrogação ou fechamento), para que caches de outros
+// repositórios sejam invalidados. Pode ser chamada com o monitor em
+// andamento
+func (ar *AuctionRepository) OnAuctionChanged(hook func(auctionId string)) {
+	if hook == nil {
+		return
+	}
+
+	ar.changeHooksMutex.Lock()
+	defer ar.changeHooksMutex.Unlock()
+	ar.changeHooks = append(ar.changeHooks, hook)
+}
+
+// Avisa as funções registradas em OnAuctionChanged que os leilões mudaram;
+// deve ser chamada sem activeAuctionsMutex adquirido
+func (ar *AuctionRepository) notifyAuctionsChanged(ids ...string) {
+	ar.changeHooksMutex.Lock()
+	hooks := ar.changeHooks
+	ar.changeHooksMutex.Unlock()
+
+	for _, hook := range hooks {
+		for _, id := range ids {
+			hook(id)
+		}
+	}
+}
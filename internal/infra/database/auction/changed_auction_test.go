@@ -0,0 +1,44 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+)
+
+func TestAuctionChangesNotifyRegisteredHooks(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo,
+		&auction_entity.Auction{Id: "cancelled", Category: "books", Status: auction_entity.Active},
+		&auction_entity.Auction{Id: "failed", Category: "books", Status: auction_entity.Active})
+	repo.trackAuction("cancelled", time.Now().Add(time.Hour), "books")
+	repo.trackAuction("failed", time.Now().Add(time.Hour), "books")
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+
+	var changed []string
+	repo.OnAuctionChanged(func(auctionId string) {
+		changed = append(changed, auctionId)
+	})
+
+	if err := repo.CancelAuction(context.Background(), "cancelled"); err != nil {
+		t.Fatalf("Expected auction to be cancelled, got %v", err)
+	}
+
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		if id == "failed" {
+			return internal_error.NewInternalServerError("Error updating auction status")
+		}
+		return nil
+	}
+	if err := repo.CancelAuction(context.Background(), "failed"); err == nil {
+		t.Fatalf("Expected the failed cancellation to return an error")
+	}
+
+	repo.checkExpiredAuctions()
+
+	if len(changed) != 2 || changed[0] != "cancelled" || changed[1] != "expired" {
+		t.Errorf("Expected only applied changes to be notified, got %v", changed)
+	}
+}
@@ -0,0 +1,100 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// ClockSource define qual relógio decide a expiração dos leilões
+type ClockSource string
+
+const (
+	// ClockLocal usa o relógio da máquina (padrão)
+	ClockLocal ClockSource = "local"
+	// ClockDatabase usa o relógio do MongoDB, o mesmo para todas as
+	// réplicas, mesmo que os relógios das máquinas estejam defasados
+	ClockDatabase ClockSource = "database"
+)
+
+// Lê a fonte do relógio de AUCTION_CLOCK_SOURCE
+func getClockSource() ClockSource {
+	if ClockSource(os.Getenv("AUCTION_CLOCK_SOURCE")) == ClockDatabase {
+		return ClockDatabase
+	}
+
+	return ClockLocal
+}
+
+// Clock fornece o horário atual usado para decidir a expiração dos
+// leilões; pode ser substituído por um relógio falso em testes
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapta uma função ao Clock
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// Relógio da máquina (padrão)
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Horário atual segundo o relógio do repositório
+func (ar *AuctionRepository) now() time.Time {
+	return ar.clock.Now()
+}
+
+// Clock retorna o relógio do repositório, para que outros repositórios
+// (ex.: lances) decidam a expiração pelo mesmo horário
+func (ar *AuctionRepository) Clock() Clock {
+	return ClockFunc(ar.now)
+}
+
+// Horário atual segundo o MongoDB: o relógio local corrigido pela diferença
+// medida na última sincronização
+func (ar *AuctionRepository) serverClockNow() time.Time {
+	return time.Now().Add(time.Duration(ar.serverClockOffset.Load()))
+}
+
+// Mede a diferença entre o relógio do MongoDB e o local. Em caso de erro a
+// última diferença conhecida continua valendo
+func (ar *AuctionRepository) syncServerClock(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, ar.opTimeout)
+	defer cancel()
+
+	sent := time.Now()
+	serverTime, err := ar.serverTime(ctx)
+	if err != nil {
+		ar.logger.Error("Error trying to read the database clock, keeping the last known offset", err)
+		return
+	}
+	received := time.Now()
+
+	// Considera que o servidor respondeu no meio da ida e volta
+	local := sent.Add(received.Sub(sent) / 2)
+	offset := serverTime.Sub(local)
+	if previous := time.Duration(ar.serverClockOffset.Swap(int64(offset))); (offset - previous).Abs() > time.Second {
+		ar.logger.Info("Database clock offset changed",
+			zap.Duration("offset", offset))
+	}
+}
+
+// Implementação real da leitura do relógio do MongoDB (localTime do hello)
+func (ar *AuctionRepository) serverTimeImpl(ctx context.Context) (time.Time, error) {
+	var result struct {
+		LocalTime time.Time `bson:"localTime"`
+	}
+
+	err := ar.Collection.Database().RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&result)
+	return result.LocalTime, err
+}
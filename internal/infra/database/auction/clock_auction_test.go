@@ -0,0 +1,84 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// Réplica com o relógio 10s atrasado: pelo relógio local o leilão ainda
+// tem 5s pela frente, mas pelo relógio do MongoDB (compartilhado pelas
+// réplicas) ele já terminou
+func TestDatabaseClockClosesAuctionsDespiteLocalSkew(t *testing.T) {
+	const skew = 10 * time.Second
+
+	for _, source := range []ClockSource{ClockLocal, ClockDatabase} {
+		repo := setupInMemoryRepository()
+		repo.clockSource = source
+		repo.serverTime = func(ctx context.Context) (time.Time, error) {
+			return time.Now().Add(skew), nil
+		}
+		if source == ClockDatabase {
+			repo.clock = ClockFunc(repo.serverClockNow)
+		}
+		repo.trackAuction("auction", time.Now().Add(5*time.Second), "books")
+
+		repo.sweep()
+
+		expectClosed := source == ClockDatabase
+		if closed := !repo.isTracked("auction"); closed != expectClosed {
+			t.Errorf("With %s clock expected closed=%v, got %v", source, expectClosed, closed)
+		}
+	}
+}
+
+func TestSyncServerClockKeepsOffsetOnError(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.serverTime = func(ctx context.Context) (time.Time, error) {
+		return time.Now().Add(time.Minute), nil
+	}
+	repo.syncServerClock(context.Background())
+
+	repo.serverTime = func(ctx context.Context) (time.Time, error) {
+		return time.Time{}, errors.New("connection refused")
+	}
+	repo.syncServerClock(context.Background())
+
+	if offset := repo.serverClockNow().Sub(time.Now()); offset < 59*time.Second || offset > 61*time.Second {
+		t.Errorf("Expected the last known offset of 1m to be kept, got %s", offset)
+	}
+}
+
+func TestGetClockSource(t *testing.T) {
+	defer os.Unsetenv("AUCTION_CLOCK_SOURCE")
+
+	os.Setenv("AUCTION_CLOCK_SOURCE", "database")
+	if source := getClockSource(); source != ClockDatabase {
+		t.Errorf("Expected database clock, got %s", source)
+	}
+
+	os.Setenv("AUCTION_CLOCK_SOURCE", "invalid")
+	if source := getClockSource(); source != ClockLocal {
+		t.Errorf("Expected local clock by default, got %s", source)
+	}
+}
+
+func TestServerTimeFromDatabase(t *testing.T) {
+	database := setupMongoDatabase(t)
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	serverTime, err := repo.serverTimeImpl(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to read the database clock: %v", err)
+	}
+
+	// Com MongoDB local os relógios devem coincidir, salvo a precisão de
+	// milissegundos do servidor
+	if diff := time.Since(serverTime).Abs(); diff > 5*time.Second {
+		t.Errorf("Expected the database clock to be close to the local one, got a %s difference", diff)
+	}
+}
@@ -0,0 +1,50 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestClockSkewToleranceKeepsAuctionOpen(t *testing.T) {
+	repo := setupInMemoryRepository()
+	endTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.activeAuctions["auction"] = endTime
+	repo.clockSkewTolerance = 2 * time.Second
+
+	// Relógio ligeiramente adiantado em relação ao fim do leilão
+	repo.clock = ClockFunc(func() time.Time { return endTime.Add(time.Second) })
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if _, exists := repo.activeAuctions["auction"]; !exists {
+		t.Fatalf("Expected auction within the skew tolerance to stay open")
+	}
+
+	repo.clock = ClockFunc(func() time.Time { return endTime.Add(3 * time.Second) })
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if _, exists := repo.activeAuctions["auction"]; exists {
+		t.Errorf("Expected auction past the skew tolerance to be closed")
+	}
+}
+
+func TestGetClockSkewTolerance(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"500ms", 500 * time.Millisecond},
+		{"-1s", 0},
+		{"invalid", 0},
+	}
+
+	for _, tc := range testCases {
+		os.Setenv("AUCTION_CLOCK_SKEW_TOLERANCE", tc.value)
+		if got := getClockSkewTolerance(); got != tc.expected {
+			t.Errorf("For %q expected %v, got %v", tc.value, tc.expected, got)
+		}
+	}
+	os.Unsetenv("AUCTION_CLOCK_SKEW_TOLERANCE")
+}
@@ -0,0 +1,30 @@
+package auction
+
+import (
+	"context"
+)
+
+// Close para a goroutine de monitoramento e espera que ela termine. Pode
+// ser chamado mais de uma vez
+func (ar *AuctionRepository) Close() {
+	ar.closeOnce.Do(func() {
+		ar.cancelFunc()
+
+		if ar.monitorStarted {
+			<-ar.monitorDone
+		}
+
+		ar.logger.Info("Auction repository closed")
+	})
+}
+
+// CloseAndFlush para o monitor e, antes de retornar, fecha os leilões que
+// já expiraram mas ainda não foram varridos, para que o desligamento não
+// deixe leilões vencidos como ativos no banco
+func (ar *AuctionRepository) CloseAndFlush() {
+	ar.Close()
+
+	ar.logger.Info("Flushing expired auctions before shutdown")
+	// O contexto do repositório já foi cancelado por Close
+	ar.processExpiredAuctions(context.Background(), 0)
+}
@@ -0,0 +1,255 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCloseAndFlushClosesExpiredAuctions(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	var closed []string
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		if status == auction_entity.Completed {
+			closed = append(closed, id)
+		}
+		return nil
+	}
+
+	repo.activeAuctions["expired"] = time.Now().Add(-time.Second)
+	repo.activeAuctions["running"] = time.Now().Add(time.Hour)
+
+	repo.CloseAndFlush()
+
+	if len(closed) != 1 || closed[0] != "expired" {
+		t.Errorf("Expected only the expired auction to be closed, got %v", closed)
+	}
+
+	if _, tracked := repo.activeAuctions["running"]; !tracked {
+		t.Errorf("Expected running auction to be left untouched")
+	}
+
+	select {
+	case <-repo.ctx.Done():
+	default:
+		t.Errorf("Expected the monitor context to be cancelled")
+	}
+}
+
+func TestCloseStopsMonitor(t *testing.T) {
+	os.Setenv("AUCTION_CHECK_INTERVAL", "100ms")
+	defer os.Unsetenv("AUCTION_CHECK_INTERVAL")
+
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "close_test"))
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return nil
+	}
+
+	// Antes do Close o monitor fecha leilões expirados
+	repo.trackAuction("before-close", time.Now().Add(-time.Second), "books")
+	deadline := time.Now().Add(time.Second)
+	for repo.isTracked("before-close") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if repo.isTracked("before-close") {
+		t.Fatalf("Expected the running monitor to close the expired auction")
+	}
+
+	repo.Close()
+	repo.Close()
+
+	select {
+	case <-repo.monitorDone:
+	default:
+		t.Fatalf("Expected Close to wait for the monitor to exit")
+	}
+
+	monitorRegistryMutex.Lock()
+	_, registered := monitorRegistry[monitorNamespace(repo.Collection)]
+	monitorRegistryMutex.Unlock()
+	if registered {
+		t.Errorf("Expected the monitor registration to be released")
+	}
+
+	// Depois do Close nenhuma varredura acontece
+	repo.trackAuction("after-close", time.Now().Add(-time.Second), "books")
+	time.Sleep(250 * time.Millisecond)
+	if !repo.isTracked("after-close") {
+		t.Errorf("Expected no sweeps after Close")
+	}
+}
+
+func TestCancelAbortsInFlightStatusUpdate(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+
+	started := make(chan struct{})
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return internal_error.NewInternalServerError("Error updating auction status")
+		case <-time.After(time.Second):
+			t.Errorf("Expected the update context to be cancelled")
+			return nil
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		repo.checkExpiredAuctions()
+		close(done)
+	}()
+
+	<-started
+	repo.cancelFunc()
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("Expected the sweep to return once the context was cancelled")
+	}
+
+	if !repo.isTracked("expired") {
+		t.Errorf("Expected the auction whose update was aborted to stay tracked")
+	}
+
+	// CloseAndFlush ainda consegue fechar o leilão depois do cancelamento
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		if ctx.Err() != nil {
+			return internal_error.NewInternalServerError("Error updating auction status")
+		}
+		return nil
+	}
+	repo.CloseAndFlush()
+
+	if repo.isTracked("expired") {
+		t.Errorf("Expected CloseAndFlush to close the auction with a fresh context")
+	}
+}
+
+func TestDryRunKeepsExpiredAuctionTracked(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	defer logger.Replace(zap.New(core))()
+
+	repo := setupInMemoryRepository()
+	repo.dryRun = true
+
+	updates := 0
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		updates++
+		return nil
+	}
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		updates++
+		return ids, nil
+	}
+
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+	repo.checkExpiredAuctions()
+
+	if !repo.isTracked("expired") {
+		t.Errorf("Expected the expired auction to stay tracked in dry run")
+	}
+
+	if updates != 0 {
+		t.Errorf("Expected no status update in dry run, got %d", updates)
+	}
+
+	entries := logs.FilterMessage("Dry run: expired auctions would be closed").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one dry run log entry, got %d", len(entries))
+	}
+	if ids, ok := entries[0].ContextMap()["auction_ids"].([]interface{}); !ok || len(ids) != 1 || ids[0] != "expired" {
+		t.Errorf("Expected the expired auction id to be logged, got %v", entries[0].ContextMap())
+	}
+}
+
+func TestCloseWithoutMonitor(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	repo.Close()
+	repo.Close()
+
+	select {
+	case <-repo.ctx.Done():
+	default:
+		t.Errorf("Expected the context to be cancelled")
+	}
+}
+
+// Informa se o leilão está no mapa de leilões em andamento
+func (ar *AuctionRepository) isTracked(id string) bool {
+	ar.activeAuctionsMutex.RLock()
+	defer ar.activeAuctionsMutex.RUnlock()
+
+	_, tracked := ar.activeAuctions[id]
+	return tracked
+}
+
+func TestMonitorRecoversFromPanickingSweep(t *testing.T) {
+	os.Setenv("AUCTION_CHECK_INTERVAL", "100ms")
+	defer os.Unsetenv("AUCTION_CHECK_INTERVAL")
+
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "panic_test"))
+	defer repo.Close()
+
+	var calls atomic.Int32
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		if calls.Add(1) == 1 {
+			panic("driver exploded")
+		}
+		return nil
+	}
+
+	// O primeiro tick entra em panic; o leilão continua no mapa e é
+	// fechado por um tick seguinte
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+	deadline := time.Now().Add(2 * time.Second)
+	for repo.isTracked("expired") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if repo.isTracked("expired") {
+		t.Fatalf("Expected the monitor to keep running after a panic and close the auction")
+	}
+	if calls.Load() < 2 {
+		t.Errorf("Expected the update to be retried on a later tick, got %d calls", calls.Load())
+	}
+
+	select {
+	case <-repo.monitorDone:
+		t.Errorf("Expected the monitor goroutine to still be running")
+	default:
+	}
+}
+
+func TestPanickingSweepDoesNotRecordTick(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		panic("driver exploded")
+	}
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+
+	repo.tick()
+	if !repo.lastTick.IsZero() {
+		t.Errorf("Expected no tick to be recorded after a panicking sweep, got %v", repo.lastTick)
+	}
+
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return nil
+	}
+	repo.tick()
+	if repo.lastTick.IsZero() {
+		t.Errorf("Expected the tick to be recorded after a successful sweep")
+	}
+}
@@ -0,0 +1,81 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AuctionClosedEvent carrega o contexto necessário para notificar o
+// encerramento de um leilão; WinnerUserId e FinalPrice ficam vazios sem
+// vencedor
+type AuctionClosedEvent struct {
+	AuctionId              string            `json:"auction_id"`
+	ClosedAt               time.Time         `json:"closed_at"`
+	WinnerUserId           string            `json:"winner_user_id,omitempty"`
+	FinalPrice             float64           `json:"final_price,omitempty"`
+	NotificationTemplateId string            `json:"notification_template_id,omitempty"`
+	NotificationMetadata   map[string]string `json:"notification_metadata,omitempty"`
+}
+
+// Dispara OnAuctionClosed, se configurado, protegendo o monitor contra
+// pânicos do callback
+func (ar *AuctionRepository) publishAuctionClosed(id string) {
+	if ar.OnAuctionClosed == nil {
+		return
+	}
+
+	event := AuctionClosedEvent{AuctionId: id, ClosedAt: ar.now()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ar.opTimeout)
+	defer cancel()
+
+	if auctionEntity, err := ar.findAuctionById(ctx, id); err != nil {
+		ar.logger.Error("Error trying to load the closed auction for its event", err,
+			zap.String("auction_id", id))
+	} else {
+		event.NotificationTemplateId = auctionEntity.NotificationTemplateId
+		event.NotificationMetadata = auctionEntity.NotificationMetadata
+		event.WinnerUserId = auctionEntity.WinnerUserId
+		event.FinalPrice = auctionEntity.FinalPrice
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ar.logger.Error("Recovered from panic in OnAuctionClosed", fmt.Errorf("%v", r),
+				zap.String("auction_id", id))
+		}
+	}()
+
+	ar.OnAuctionClosed(event)
+}
+
+// WithOnAuctionClosed define OnAuctionClosed antes de o monitor iniciar,
+// evitando que uma varredura leia o campo enquanto ele é atribuído
+func WithOnAuctionClosed(hook func(event AuctionClosedEvent)) Option {
+	return func(ar *AuctionRepository) {
+		ar.OnAuctionClosed = hook
+	}
+}
+
+// Notifier entrega os eventos de encerramento a sistemas externos
+// (ex.: webhook)
+type Notifier interface {
+	Notify(ctx context.Context, event AuctionClosedEvent) error
+}
+
+// NotifyInBackground adapta um Notifier para OnAuctionClosed; cada evento é
+// entregue em uma goroutine para não atrasar o monitor com as retentativas
+func NotifyInBackground(notifier Notifier) func(event AuctionClosedEvent) {
+	return func(event AuctionClosedEvent) {
+		go func() {
+			if err := notifier.Notify(context.Background(), event); err != nil {
+				logger.Error("Error notifying the close of the auction", err,
+					zap.String("auction_id", event.AuctionId))
+			}
+		}()
+	}
+}
@@ -0,0 +1,174 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAuctionClosedEventCarriesNotificationTemplate(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo, &auction_entity.Auction{
+		Id:                     "auction",
+		NotificationTemplateId: "auction_won",
+		NotificationMetadata:   map[string]string{"seller": "Jane"},
+	})
+
+	var events []AuctionClosedEvent
+	WithOnAuctionClosed(func(event AuctionClosedEvent) {
+		events = append(events, event)
+	})(repo)
+
+	repo.activeAuctions["auction"] = time.Now().Add(-time.Second)
+	repo.checkExpiredAuctions()
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 close event, got %d", len(events))
+	}
+
+	if events[0].AuctionId != "auction" {
+		t.Errorf("Expected event for auction, got %s", events[0].AuctionId)
+	}
+
+	if events[0].NotificationTemplateId != "auction_won" {
+		t.Errorf("Expected template auction_won, got %s", events[0].NotificationTemplateId)
+	}
+
+	if events[0].NotificationMetadata["seller"] != "Jane" {
+		t.Errorf("Expected metadata to propagate, got %v", events[0].NotificationMetadata)
+	}
+}
+
+func TestAuctionClosedEventCarriesWinner(t *testing.T) {
+	repo := setupInMemoryRepository()
+	sold := &auction_entity.Auction{Id: "sold"}
+	stubFindAuctionById(repo, sold, &auction_entity.Auction{Id: "unsold"})
+
+	repo.findHighestBid = func(ctx context.Context, auctionId string) (*auctionWinner, error) {
+		if auctionId == "sold" {
+			return &auctionWinner{BidId: "bid", UserId: "winner", Amount: 100}, nil
+		}
+		return nil, nil
+	}
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		sold.WinnerUserId = update["$set"].(bson.M)["winner_user_id"].(string)
+		return 1, nil
+	}
+
+	winners := map[string]string{}
+	repo.OnAuctionClosed = func(event AuctionClosedEvent) {
+		winners[event.AuctionId] = event.WinnerUserId
+	}
+
+	repo.trackAuction("sold", time.Now().Add(-time.Second), "books")
+	repo.trackAuction("unsold", time.Now().Add(-time.Second), "books")
+	repo.checkExpiredAuctions()
+
+	if winner, fired := winners["sold"]; !fired || winner != "winner" {
+		t.Errorf("Expected close event for sold with winner, got %q (fired: %v)", winner, fired)
+	}
+
+	if winner, fired := winners["unsold"]; !fired || winner != "" {
+		t.Errorf("Expected close event for unsold without winner, got %q (fired: %v)", winner, fired)
+	}
+}
+
+func TestAuctionClosedHookPanicDoesNotStopSweep(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo)
+
+	calls := 0
+	repo.OnAuctionClosed = func(event AuctionClosedEvent) {
+		calls++
+		panic("subscriber failure")
+	}
+
+	repo.activeAuctions["first"] = time.Now().Add(-time.Second)
+	repo.activeAuctions["second"] = time.Now().Add(-time.Second)
+	repo.checkExpiredAuctions()
+
+	if calls != 2 {
+		t.Errorf("Expected the hook to be called for both auctions, got %d calls", calls)
+	}
+}
+
+type notifierFunc func(ctx context.Context, event AuctionClosedEvent) error
+
+func (f notifierFunc) Notify(ctx context.Context, event AuctionClosedEvent) error {
+	return f(ctx, event)
+}
+
+func TestNotifyInBackground(t *testing.T) {
+	delivered := make(chan AuctionClosedEvent, 1)
+	hook := NotifyInBackground(notifierFunc(func(ctx context.Context, event AuctionClosedEvent) error {
+		delivered <- event
+		return nil
+	}))
+
+	hook(AuctionClosedEvent{AuctionId: "auction"})
+
+	select {
+	case event := <-delivered:
+		if event.AuctionId != "auction" {
+			t.Errorf("Expected event for auction, got %s", event.AuctionId)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the notifier to receive the event")
+	}
+}
+
+// Core que avisa quando o logger é sincronizado, o último acesso de
+// logger.Error ao logger global
+type syncSignalCore struct {
+	zapcore.Core
+	synced chan struct{}
+}
+
+func (c syncSignalCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c syncSignalCore) Sync() error {
+	c.synced <- struct{}{}
+	return c.Core.Sync()
+}
+
+func TestNotifyInBackgroundLogsFailures(t *testing.T) {
+	observed, logs := observer.New(zap.ErrorLevel)
+	core := syncSignalCore{Core: observed, synced: make(chan struct{}, 1)}
+	defer logger.Replace(zap.New(core))()
+
+	hook := NotifyInBackground(notifierFunc(func(ctx context.Context, event AuctionClosedEvent) error {
+		return errors.New("delivery failed")
+	}))
+
+	hook(AuctionClosedEvent{AuctionId: "auction"})
+
+	// Espera o erro ser registrado, para que a goroutine não use o logger
+	// global depois que o original for restaurado
+	select {
+	case <-core.synced:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the delivery failure to be logged")
+	}
+
+	entries := logs.All()
+	if len(entries) != 1 || entries[0].Message != "Error notifying the close of the auction" {
+		t.Fatalf("Expected the delivery failure to be logged, got %v", entries)
+	}
+	fields := entries[0].ContextMap()
+	if err, ok := fields["error"]; !ok || err != "delivery failed" || fields["auction_id"] != "auction" {
+		t.Errorf("Expected the notifier error and the auction id in the log, got %v", fields)
+	}
+}
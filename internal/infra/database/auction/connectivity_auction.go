@@ -0,0 +1,23 @@
+package auction
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// Identifica falhas de conexão com o MongoDB (rede, timeout, seleção de
+// servidor), diferentes de erros de um documento específico
+func isConnectivityError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if mongo.IsNetworkError(err) || mongo.IsTimeout(err) || errors.Is(err, mongo.ErrClientDisconnected) {
+		return true
+	}
+
+	var selectionErr topology.ServerSelectionError
+	return errors.As(err, &selectionErr)
+}
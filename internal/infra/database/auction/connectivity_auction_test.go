@@ -0,0 +1,86 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIsConnectivityError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"network", mongo.CommandError{Labels: []string{"NetworkError"}}, true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"client disconnected", mongo.ErrClientDisconnected, true},
+		{"duplicate key", mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}, false},
+		{"generic", errors.New("document failed validation"), false},
+	}
+
+	for _, tc := range testCases {
+		if got := isConnectivityError(tc.err); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestExpiredAuctionsRequeuedDuringOutage(t *testing.T) {
+	repo := setupInMemoryRepository()
+	past := time.Now().Add(-time.Minute)
+	repo.trackAuction("first", past, "books")
+	repo.trackAuction("second", past, "books")
+
+	databaseDown := true
+	var closed []string
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		if databaseDown {
+			return internal_error.NewServiceUnavailableError("Database unavailable")
+		}
+		closed = append(closed, id)
+		return nil
+	}
+
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if len(repo.activeAuctions) != 2 {
+		t.Fatalf("Expected both auctions to stay tracked during the outage, got %d", len(repo.activeAuctions))
+	}
+
+	if got := repo.categoryGauge.Value("books", "active"); got != 2 {
+		t.Errorf("Expected active gauge to stay at 2, got %v", got)
+	}
+
+	databaseDown = false
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if len(repo.activeAuctions) != 0 {
+		t.Errorf("Expected auctions to close once the database recovers, %d still tracked", len(repo.activeAuctions))
+	}
+
+	if len(closed) != 2 {
+		t.Errorf("Expected 2 auctions closed, got %v", closed)
+	}
+}
+
+func TestPerDocumentCloseErrorIsNotRequeued(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.trackAuction("broken", time.Now().Add(-time.Minute), "books")
+
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return internal_error.NewInternalServerError("Error updating auction status")
+	}
+
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if _, exists := repo.activeAuctions["broken"]; exists {
+		t.Errorf("Expected a per-document failure not to be requeued")
+	}
+}
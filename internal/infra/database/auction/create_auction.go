@@ -2,26 +2,131 @@ package auction
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/configuration/metrics"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"math/rand"
 	"os"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
 )
 
 type AuctionEntityMongo struct {
-	Id          string                          `bson:"_id"`
-	ProductName string                          `bson:"product_name"`
-	Category    string                          `bson:"category"`
-	Description string                          `bson:"description"`
-	Condition   auction_entity.ProductCondition `bson:"condition"`
-	Status      auction_entity.AuctionStatus    `bson:"status"`
-	Timestamp   int64                           `bson:"timestamp"`
+	Id                     string                          `bson:"_id"`
+	SellerId               string                          `bson:"seller_id,omitempty"`
+	IdempotencyKey         string                          `bson:"idempotency_key,omitempty"`
+	ProductName            string                          `bson:"product_name"`
+	Category               string                          `bson:"category"`
+	CategoryDisplay        string                          `bson:"category_display,omitempty"`
+	Categories             []string                        `bson:"categories,omitempty"`
+	Description            string                          `bson:"description"`
+	Condition              auction_entity.ProductCondition `bson:"condition"`
+	Status                 auction_entity.AuctionStatus    `bson:"status"`
+	Timestamp              int64                           `bson:"timestamp"`
+	Views                  int64                           `bson:"views"`
+	NotificationTemplateId string                          `bson:"notification_template_id,omitempty"`
+	NotificationMetadata   map[string]string               `bson:"notification_metadata,omitempty"`
+	RelistedFrom           string                          `bson:"relisted_from,omitempty"`
+	RelistCount            int                             `bson:"relist_count,omitempty"`
+	BuyNowPrice            float64                         `bson:"buy_now_price,omitempty"`
+	ReservePrice           float64                         `bson:"reserve_price,omitempty"`
+	StartingBid            float64                         `bson:"starting_bid,omitempty"`
+	WinnerBidId            string                          `bson:"winner_bid_id,omitempty"`
+	WinnerUserId           string                          `bson:"winner_user_id,omitempty"`
+	ReserveNotMet          bool                            `bson:"reserve_not_met,omitempty"`
+	FinalPrice             float64                         `bson:"final_price,omitempty"`
+	// Duração escolhida pelo vendedor; ausente usa AUCTION_INTERVAL
+	Duration time.Duration `bson:"duration,omitempty"`
+	// Término gravado na criação (ou publicação); ausente em rascunhos e em
+	// documentos antigos, cujo término é calculado a partir de timestamp
+	EndTime int64 `bson:"end_time,omitempty"`
+	// Quando o leilão foi pausado, para descontar a pausa ao retomá-lo
+	PausedAt int64 `bson:"paused_at,omitempty"`
+	// Início agendado de leilões Scheduled
+	StartTime int64 `bson:"start_time,omitempty"`
+	// Marcado antes da inserção do primeiro lance; impede a edição do
+	// leilão na mesma escrita que a grava (ver UpdateAuction)
+	HasBids bool `bson:"has_bids,omitempty"`
+}
+
+// Rejeita documentos com status ou condição fora da enumeração (ex.:
+// gravados à mão ou corrompidos), que não podem virar uma entidade válida
+func validateEnums(
+	id string,
+	status auction_entity.AuctionStatus,
+	condition auction_entity.ProductCondition) *internal_error.InternalError {
+	if !status.Valid() || !condition.Valid() {
+		logger.Error("Auction has an invalid status or condition", nil,
+			zap.String("auction_id", id),
+			zap.Int("status", int(status)),
+			zap.Int("condition", int(condition)))
+		return internal_error.NewInternalServerError(
+			fmt.Sprintf("Auction %s has an invalid status or condition", id))
+	}
+
+	return nil
+}
+
+// ToEntity converte o documento; sem end_time gravado o término usa a
+// duração padrão de AUCTION_INTERVAL
+func (am *AuctionEntityMongo) ToEntity() *auction_entity.Auction {
+	return am.toEntity(getAuctionDuration())
+}
+
+// Converte o documento calculando o término, quando não gravado, com a
+// duração padrão informada (ex.: a do repositório, definida por WithDuration)
+func (am *AuctionEntityMongo) toEntity(defaultDuration time.Duration) *auction_entity.Auction {
+	auctionEntity := &auction_entity.Auction{
+		Id:                     am.Id,
+		SellerId:               am.SellerId,
+		IdempotencyKey:         am.IdempotencyKey,
+		ProductName:            am.ProductName,
+		Category:               am.Category,
+		CategoryDisplay:        am.CategoryDisplay,
+		Categories:             am.Categories,
+		Description:            am.Description,
+		Condition:              am.Condition,
+		Status:                 am.Status,
+		Timestamp:              time.Unix(am.Timestamp, 0),
+		Duration:               am.Duration,
+		Views:                  am.Views,
+		NotificationTemplateId: am.NotificationTemplateId,
+		NotificationMetadata:   am.NotificationMetadata,
+		RelistedFrom:           am.RelistedFrom,
+		RelistCount:            am.RelistCount,
+		BuyNowPrice:            am.BuyNowPrice,
+		WinnerBidId:            am.WinnerBidId,
+		WinnerUserId:           am.WinnerUserId,
+		ReservePrice:           am.ReservePrice,
+		StartingBid:            am.StartingBid,
+		ReserveNotMet:          am.ReserveNotMet,
+		FinalPrice:             am.FinalPrice,
+	}
+
+	if am.EndTime != 0 {
+		auctionEntity.EndTime = time.Unix(am.EndTime, 0)
+	} else if auctionEntity.Status != auction_entity.Draft && auctionEntity.Status != auction_entity.Scheduled {
+		auctionEntity.EndTime = auctionEndTime(auctionEntity, defaultDuration)
+	}
+
+	if am.PausedAt != 0 {
+		auctionEntity.PausedAt = time.Unix(am.PausedAt, 0)
+	}
+
+	if am.StartTime != 0 {
+		auctionEntity.StartTime = time.Unix(am.StartTime, 0)
+	}
+
+	return auctionEntity
 }
 
 type AuctionRepository struct {
@@ -29,144 +134,727 @@ type AuctionRepository struct {
 	// Para manter o controle de leilões em andamento
 	activeAuctions      map[string]time.Time
 	activeAuctionsMutex *sync.RWMutex
+	// Categoria de cada leilão em andamento, usada nas métricas
+	activeCategories map[string]string
+	// Leilões reservados por uma transição em curso (também protegido por
+	// activeAuctionsMutex)
+	transitioning map[string]struct{}
+	// Funções avisadas a cada mudança de um leilão (OnAuctionChanged)
+	changeHooks      []func(auctionId string)
+	changeHooksMutex *sync.Mutex
+	// Escolhe o vencedor na moeda base (UseWinningBidFinder)
+	winningBidFinder      WinningBidFinder
+	winningBidFinderMutex *sync.RWMutex
 	// Contexto para gerenciar o ciclo de vida das goroutines
 	ctx        context.Context
 	cancelFunc context.CancelFunc
+	// Indica se esta instância iniciou a goroutine de monitoramento
+	monitorStarted bool
+	// Início do monitor e horário da última varredura, usados em Health
+	monitorStartedAt time.Time
+	lastTick         time.Time
+	healthMutex      *sync.Mutex
+	// Fechado pela goroutine de monitoramento ao terminar
+	monitorDone chan struct{}
+	closeOnce   *sync.Once
+	// Com AUCTION_DRY_RUN=true o monitor apenas registra os leilões que
+	// fecharia, sem alterar o banco nem o mapa
+	dryRun bool
+	// Intervalo entre as varreduras do monitor e a variação aleatória
+	// aplicada a cada uma, para que réplicas não consultem o banco juntas
+	checkInterval time.Duration
+	checkJitter   time.Duration
+	// Tempo máximo de cada operação no MongoDB (MONGO_OP_TIMEOUT)
+	opTimeout time.Duration
+	// Estratégia usada pelo monitor para encontrar leilões expirados
+	sweepStrategy SweepStrategy
+	// Envia dicas de índice em FindAuctions (AUCTION_FIND_INDEX_HINTS=true)
+	useIndexHints bool
+	// Tempo máximo de processamento de cada varredura (0 desativa o limite)
+	sweepBudget time.Duration
+	// Quantos leilões expirados cada atualização em lote fecha
+	batchCloseSize int
+	// Quantas vezes um leilão não vendido é publicado de novo (0 desativa)
+	maxRelists int
+	// Tolerância para relógios dessincronizados ao comparar o fim do leilão
+	clockSkewTolerance time.Duration
+	// Tolerância após o fim em que lances atrasados ainda são aceitos; o
+	// fechamento é adiado pelo mesmo tempo
+	gracePeriod time.Duration
+	// Janela final em que um lance prorroga o leilão e quanto ele prorroga
+	extensionWindow   time.Duration
+	extensionDuration time.Duration
+	// Caixa das categorias gravadas e pesquisadas (AUCTION_CATEGORY_CASE)
+	categoryCase auction_entity.CategoryCase
+	// Mínimo de palavras na descrição (AUCTION_MIN_DESCRIPTION_WORDS; 0 desativa)
+	minDescriptionWords int
+	// Encerra o leilão quando um lance atinge a compra imediata
+	// (AUCTION_INSTANT_CLOSE=true)
+	instantClose bool
+	// Relógio usado para decidir a expiração - pode ser substituído em testes
+	clock Clock
+	// Com ClockDatabase, o relógio segue o relógio do MongoDB, sincronizado a cada
+	// varredura pela diferença (em nanossegundos) para o relógio local
+	clockSource       ClockSource
+	serverClockOffset atomic.Int64
+	serverTime        func(ctx context.Context) (time.Time, error)
+	// Janela para ignorar visualizações repetidas do mesmo usuário
+	viewDedupWindow  time.Duration
+	recentViews      map[string]time.Time
+	recentViewsMutex *sync.Mutex
+	// Gauge de leilões por categoria e status, com cardinalidade limitada
+	categoryGauge       *metrics.GaugeVec
+	metricCategories    map[string]struct{}
+	maxMetricCategories int
+	metricsMutex        *sync.Mutex
+	// Contadores de leilões criados e fechados (padrão: descarta)
+	Metrics metrics.AuctionMetrics
+	// Logger usado pelo repositório (nil usa o logger global)
+	logger *logger.Logger
+	// Duração padrão dos leilões (0 lê AUCTION_INTERVAL)
+	auctionDuration time.Duration
+	// Não inicia a goroutine de monitoramento (WithoutMonitor)
+	withoutMonitor bool
 	// Função para atualizar status do leilão - pode ser substituída em testes
-	updateAuctionStatus func(id string, status auction_entity.AuctionStatus) *internal_error.InternalError
+	updateAuctionStatus func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError
+	// Atualiza o status de vários leilões de uma vez e retorna os que mudaram - pode ser substituída em testes
+	updateAuctionsStatus func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError)
+	// Chamada após o fechamento automático de um leilão (opcional)
+	OnAuctionClosed func(event AuctionClosedEvent)
+	// Funções de escrita no banco - podem ser substituídas em testes
+	insertAuction func(ctx context.Context, auction *AuctionEntityMongo) error
+	updateAuction func(ctx context.Context, filter, update bson.M) (int64, error)
+	// Função para buscar um leilão pelo id - pode ser substituída em testes
+	findAuctionById func(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError)
+	// Conta os lances do leilão (total e até o término) - pode ser substituída em testes
+	countAuctionBids func(ctx context.Context, auctionId string, endTime time.Time) (total, beforeEnd int64, err error)
+	// Busca o maior lance do leilão (nil sem lances) - pode ser substituída em testes
+	findHighestBid func(ctx context.Context, auctionId string) (*auctionWinner, error)
+	// Busca leilões ativos já vencidos no banco - pode ser substituída em testes
+	findExpiredAuctions func(ctx context.Context) ([]auction_entity.Auction, *internal_error.InternalError)
+	// Busca leilões agendados cujo início já chegou - pode ser substituída em testes
+	findDueScheduledAuctions func(ctx context.Context, now time.Time) ([]auction_entity.Auction, *internal_error.InternalError)
+	// Busca o leilão criado com a chave de idempotência - pode ser substituída em testes
+	findAuctionByIdempotencyKey func(
+		ctx context.Context, sellerId, key string) (*auction_entity.Auction, *internal_error.InternalError)
 }
 
-func NewAuctionRepository(database *mongo.Database) *AuctionRepository {
+// Option configura o AuctionRepository na criação
+type Option func(*AuctionRepository)
+
+// WithLogger faz o repositório registrar suas mensagens no logger informado
+// em vez do logger global
+func WithLogger(log *zap.Logger) Option {
+	return func(ar *AuctionRepository) {
+		ar.logger = logger.New(log)
+	}
+}
+
+// WithCheckInterval define a frequência das verificações do monitor no
+// lugar de AUCTION_CHECK_INTERVAL; valores <= 0 são ignorados
+func WithCheckInterval(interval time.Duration) Option {
+	return func(ar *AuctionRepository) {
+		if interval <= 0 {
+			return
+		}
+		ar.checkInterval = interval
+		// Sem AUCTION_SWEEP_BUDGET o orçamento acompanha o intervalo
+		if _, err := time.ParseDuration(os.Getenv("AUCTION_SWEEP_BUDGET")); err != nil {
+			ar.sweepBudget = interval / 2
+		}
+	}
+}
+
+// WithDuration define a duração padrão dos leilões no lugar de
+// AUCTION_INTERVAL; valores <= 0 são ignorados
+func WithDuration(duration time.Duration) Option {
+	return func(ar *AuctionRepository) {
+		if duration > 0 {
+			ar.auctionDuration = duration
+		}
+	}
+}
+
+// WithoutMonitor cria o repositório sem a goroutine que fecha os leilões
+// expirados (ex.: em testes ou em réplicas que só atendem a API)
+func WithoutMonitor() Option {
+	return func(ar *AuctionRepository) {
+		ar.withoutMonitor = true
+	}
+}
+
+// WithClock define a fonte do horário atual usada para decidir a expiração,
+// com precedência sobre AUCTION_CLOCK_SOURCE
+func WithClock(clock Clock) Option {
+	return func(ar *AuctionRepository) {
+		if clock != nil {
+			ar.clock = clock
+		}
+	}
+}
+
+// WithMetrics define onde registrar os contadores de leilões criados e
+// fechados; nil mantém o padrão, que descarta as medições
+func WithMetrics(m metrics.AuctionMetrics) Option {
+	return func(ar *AuctionRepository) {
+		if m != nil {
+			ar.Metrics = m
+		}
+	}
+}
+
+func NewAuctionRepository(database *mongo.Database, opts ...Option) *AuctionRepository {
+	repo := newAuctionRepository(database.Collection("auctions"))
+	for _, opt := range opts {
+		opt(repo)
+	}
+
+	if repo.withoutMonitor {
+		return repo
+	}
+
+	// Inicia a goroutine para monitorar e fechar leilões expirados, a menos
+	// que outro monitor já esteja ativo para a mesma coleção
+	namespace := monitorNamespace(repo.Collection)
+	if registerMonitor(namespace) {
+		repo.monitorStarted = true
+		repo.monitorStartedAt = time.Now()
+		repo.reloadActiveAuctions()
+		go repo.monitorAuctions()
+	} else {
+		repo.logger.Warn("Auction monitor already running, not starting another one; "+
+			"set AUCTION_ALLOW_MULTIPLE_MONITORS=true to allow it",
+			zap.String("namespace", namespace))
+	}
+
+	return repo
+}
+
+// Monta o repositório com a configuração do ambiente e as implementações
+// reais de acesso ao banco, sem iniciar o monitor
+func newAuctionRepository(collection *mongo.Collection) *AuctionRepository {
 	ctx, cancel := context.WithCancel(context.Background())
 	repo := &AuctionRepository{
-		Collection:         database.Collection("auctions"),
-		activeAuctions:     make(map[string]time.Time),
-		activeAuctionsMutex: &sync.RWMutex{},
-		ctx:                ctx,
-		cancelFunc:         cancel,
+		Collection:            collection,
+		activeAuctions:        make(map[string]time.Time),
+		activeAuctionsMutex:   &sync.RWMutex{},
+		ctx:                   ctx,
+		cancelFunc:            cancel,
+		monitorDone:           make(chan struct{}),
+		closeOnce:             &sync.Once{},
+		healthMutex:           &sync.Mutex{},
+		checkInterval:         getCheckInterval(),
+		checkJitter:           getCheckJitter(),
+		dryRun:                os.Getenv("AUCTION_DRY_RUN") == "true",
+		opTimeout:             getOpTimeout(),
+		sweepStrategy:         getSweepStrategy(),
+		sweepBudget:           getSweepBudget(),
+		batchCloseSize:        defaultBatchCloseSize,
+		clockSkewTolerance:    getClockSkewTolerance(),
+		gracePeriod:           getGracePeriod(),
+		extensionWindow:       getExtensionWindow(),
+		extensionDuration:     getExtensionDuration(),
+		instantClose:          getInstantClose(),
+		categoryCase:          auction_entity.ParseCategoryCase(os.Getenv("AUCTION_CATEGORY_CASE")),
+		minDescriptionWords:   getMinDescriptionWords(),
+		maxRelists:            getMaxRelists(),
+		clock:                 systemClock{},
+		clockSource:           getClockSource(),
+		useIndexHints:         os.Getenv("AUCTION_FIND_INDEX_HINTS") == "true",
+		viewDedupWindow:       getViewDedupWindow(),
+		recentViews:           make(map[string]time.Time),
+		recentViewsMutex:      &sync.Mutex{},
+		activeCategories:      make(map[string]string),
+		transitioning:         make(map[string]struct{}),
+		changeHooksMutex:      &sync.Mutex{},
+		winningBidFinderMutex: &sync.RWMutex{},
+		categoryGauge:         newCategoryGauge(),
+		metricCategories:      make(map[string]struct{}),
+		maxMetricCategories:   getMaxMetricCategories(),
+		metricsMutex:          &sync.Mutex{},
+		Metrics:               metrics.NoopAuctionMetrics{},
 	}
-	
+
 	// Define a função padrão para atualizar o status
 	repo.updateAuctionStatus = repo.updateAuctionStatusImpl
-	
-	// Inicia a goroutine para monitorar e fechar leilões expirados
-	go repo.monitorAuctions()
-	
+	repo.updateAuctionsStatus = repo.updateAuctionsStatusImpl
+	repo.findAuctionById = repo.FindAuctionById
+	repo.insertAuction = repo.insertAuctionImpl
+	repo.updateAuction = repo.updateAuctionImpl
+	repo.countAuctionBids = repo.countAuctionBidsImpl
+	repo.findHighestBid = repo.findHighestBidImpl
+	repo.serverTime = repo.serverTimeImpl
+	repo.findAuctionByIdempotencyKey = repo.findAuctionByIdempotencyKeyImpl
+	repo.findExpiredAuctions = repo.FindExpiredAuctions
+	repo.findDueScheduledAuctions = repo.findDueScheduledAuctionsImpl
+	if repo.clockSource == ClockDatabase {
+		repo.clock = ClockFunc(repo.serverClockNow)
+	}
+
 	return repo
 }
 
 // Função que monitora os leilões ativos e fecha aqueles que expiraram
 func (ar *AuctionRepository) monitorAuctions() {
-	logger.Info("Starting auction monitoring routine")
-	
-	// Intervalo de verificação (por padrão a cada 5 segundos)
-	interval := getCheckInterval()
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-	
+	ar.logger.Info("Starting auction monitoring routine")
+	defer close(ar.monitorDone)
+	defer releaseMonitor(monitorNamespace(ar.Collection))
+
+	timer := time.NewTimer(ar.nextCheckDelay())
+	defer timer.Stop()
+
 	for {
 		select {
 		case <-ar.ctx.Done():
-			logger.Info("Stopping auction monitoring routine")
+			ar.logger.Info("Stopping auction monitoring routine")
 			return
-		case <-ticker.C:
-			ar.checkExpiredAuctions()
+		case <-timer.C:
+			ar.tick()
+			timer.Reset(ar.nextCheckDelay())
+		}
+	}
+}
+
+// Executa uma varredura e só registra o tick se ela terminou sem panic,
+// para que /health aponte um monitor que falha em todas as varreduras
+func (ar *AuctionRepository) tick() {
+	if ar.safeSweep() {
+		ar.recordTick()
+	}
+}
+
+// Executa uma varredura recuperando eventuais panics, para que uma falha
+// em um tick não encerre a goroutine e interrompa o fechamento automático;
+// retorna false quando a varredura entrou em panic
+func (ar *AuctionRepository) safeSweep() (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ar.logger.Error("Recovered from panic in auction monitoring routine",
+				fmt.Errorf("%v", r), zap.Stack("stack"))
+			ok = false
 		}
+	}()
+
+	ar.sweep()
+	return true
+}
+
+// Intervalo até a próxima varredura: checkInterval deslocado por um valor
+// aleatório entre -checkJitter e +checkJitter
+func (ar *AuctionRepository) nextCheckDelay() time.Duration {
+	jitter := ar.checkJitter
+	if jitter > ar.checkInterval/2 {
+		jitter = ar.checkInterval / 2
+	}
+	if jitter <= 0 {
+		return ar.checkInterval
 	}
+
+	return ar.checkInterval - jitter + time.Duration(rand.Int63n(int64(2*jitter)+1))
 }
 
 // Verifica e fecha leilões expirados
 func (ar *AuctionRepository) checkExpiredAuctions() {
-	now := time.Now()
+	// No modo de simulação apenas registra o que seria fechado
+	if ar.dryRun {
+		if ids := ar.findExpiredAuctionIds(ar.now()); len(ids) > 0 {
+			ar.logger.Info("Dry run: expired auctions would be closed",
+				zap.Strings("auction_ids", ids))
+		}
+		return
+	}
+
+	ar.processExpiredAuctions(ar.ctx, ar.sweepBudget)
+	ar.Metrics.SetActiveAuctions(ar.ActiveAuctionCount())
+}
+
+// Fecha os leilões expirados respeitando o orçamento de tempo informado
+// (0 processa todos). Se ctx for cancelado, as atualizações em andamento
+// são abortadas e os leilões restantes continuam no mapa
+func (ar *AuctionRepository) processExpiredAuctions(ctx context.Context, budget time.Duration) {
+	started := time.Now()
+	expiredAuctionIds := ar.findExpiredAuctionIds(ar.now())
+
+	// Os leilões são fechados em lotes de até batchCloseSize, cada um com
+	// uma única atualização; o orçamento é conferido entre os lotes e os
+	// restantes continuam no mapa para o próximo tick
+	for start := 0; start < len(expiredAuctionIds); start += ar.batchCloseSize {
+		if budget > 0 && time.Since(started) > budget {
+			ar.logger.Info("Sweep budget exceeded, deferring expired auctions to the next tick",
+				zap.Duration("budget", budget),
+				zap.Int("auctions", len(expiredAuctionIds)-start))
+			return
+		}
+
+		end := start + ar.batchCloseSize
+		if end > len(expiredAuctionIds) {
+			end = len(expiredAuctionIds)
+		}
+		if !ar.closeExpiredChunk(ctx, expiredAuctionIds[start:end], len(expiredAuctionIds)-start, started, budget) {
+			return
+		}
+	}
+}
+
+// Fecha um lote de leilões expirados; remaining é quantos ainda faltam na
+// varredura, incluindo o lote. Retorna false quando a varredura deve parar
+// (desligamento, banco indisponível ou orçamento esgotado)
+func (ar *AuctionRepository) closeExpiredChunk(
+	ctx context.Context, ids []string, remaining int, started time.Time, budget time.Duration) bool {
+	if len(ids) > 1 {
+		closed, skipped, err := ar.closeExpiredInBatch(ctx, ids)
+		if ctx.Err() != nil {
+			ar.logger.Info("Sweep cancelled, keeping expired auctions tracked",
+				zap.Int("auctions", remaining))
+			return false
+		}
+		if err == nil {
+			for _, auction := range closed {
+				ar.finishExpiredAuction(auction.id, auction.category, true)
+			}
+			for _, auction := range skipped {
+				ar.logger.Info("Skipping expired auction closed by another operation",
+					zap.String("auction_id", auction.id))
+				ar.recordCategoryMetric(auction.category, auction_entity.Active, -1)
+			}
+			return true
+		}
+
+		if errors.Is(err, internal_error.ErrServiceUnavailable) {
+			ar.logger.Warn("Database unavailable, requeueing expired auctions for the next tick",
+				zap.Int("auctions", remaining))
+			return false
+		}
+
+		// Em qualquer outro erro, tenta fechar cada leilão individualmente
+		ar.logger.Error("Failed to close expired auctions in batch, falling back to per-auction updates", err,
+			zap.Int("auctions", len(ids)))
+	}
+
+	for i, id := range ids {
+		// Sem lote, o orçamento também é conferido leilão a leilão
+		if i > 0 && budget > 0 && time.Since(started) > budget {
+			ar.logger.Info("Sweep budget exceeded, deferring expired auctions to the next tick",
+				zap.Duration("budget", budget),
+				zap.Int("auctions", remaining-i))
+			return false
+		}
+
+		// Atualiza o status no banco e remove do mapa na mesma transição; se
+		// o id já saiu do mapa, outra varredura (ou um cancelamento) já o
+		// processou e nada é feito
+		var category string
+		closedHere, err := ar.transition(ctx, id, auction_entity.Completed,
+			func() bool { return ar.isTrackedLocked(id) },
+			func() { category, _ = ar.untrackLocked(id) })
+		if ctx.Err() != nil {
+			// Desligamento: a atualização foi abortada e o leilão continua
+			// no mapa, para ser fechado por CloseAndFlush ou na reinicialização
+			ar.logger.Info("Sweep cancelled, keeping expired auctions tracked",
+				zap.Int("auctions", remaining-i))
+			return false
+		} else if errors.Is(err, internal_error.ErrServiceUnavailable) {
+			// Banco inacessível: o leilão continua no mapa e os demais
+			// ficam para o próximo tick, quando o MongoDB deve ter voltado
+			ar.logger.Warn("Database unavailable, requeueing expired auctions for the next tick",
+				zap.Int("auctions", remaining-i))
+			return false
+		} else if err != nil && err.Code == "auction.transition_in_progress" {
+			// Outra operação está mudando o leilão; se ele continuar no mapa,
+			// é conferido de novo no próximo tick
+			continue
+		} else if err != nil {
+			ar.logger.Error("Failed to close expired auction", err,
+				zap.String("auction_id", id))
+
+			ar.activeAuctionsMutex.Lock()
+			category, wasTracked := ar.untrackLocked(id)
+			ar.activeAuctionsMutex.Unlock()
+			if wasTracked {
+				ar.recordCategoryMetric(category, auction_entity.Active, -1)
+			}
+		} else if closedHere {
+			ar.finishExpiredAuction(id, category, true)
+		} else {
+			// O leilão foi fechado por outra operação (ex.: cancelado) depois
+			// de ser coletado; sai do mapa sem ser concluído
+			ar.activeAuctionsMutex.Lock()
+			category, wasTracked := ar.untrackLocked(id)
+			ar.activeAuctionsMutex.Unlock()
+			if wasTracked {
+				ar.logger.Info("Skipping expired auction closed by another operation",
+					zap.String("auction_id", id))
+				ar.recordCategoryMetric(category, auction_entity.Active, -1)
+			}
+		}
+	}
+
+	return true
+}
+
+// Coleta os IDs de leilões expirados com lock de leitura; a tolerância de
+// relógio e o período de tolerância para lances adiam o fechamento
+func (ar *AuctionRepository) findExpiredAuctionIds(now time.Time) []string {
 	var expiredAuctionIds []string
-	
-	// Coleta os IDs de leilões expirados com lock de leitura
+
 	ar.activeAuctionsMutex.RLock()
 	for id, endTime := range ar.activeAuctions {
-		if now.After(endTime) {
+		// Leilões reservados por outra transição ficam para o próximo tick
+		if now.After(endTime.Add(ar.clockSkewTolerance+ar.gracePeriod)) && !ar.isReservedLocked(id) {
 			expiredAuctionIds = append(expiredAuctionIds, id)
 		}
 	}
 	ar.activeAuctionsMutex.RUnlock()
-	
-	// Processa cada leilão expirado
-	for _, id := range expiredAuctionIds {
-		// Remove do mapa com lock de escrita
-		ar.activeAuctionsMutex.Lock()
-		delete(ar.activeAuctions, id)
-		ar.activeAuctionsMutex.Unlock()
-		
-		// Atualiza o status no banco de dados
-		err := ar.updateAuctionStatus(id, auction_entity.Completed)
-		if err != nil {
-			logger.Error(fmt.Sprintf("Failed to close expired auction: %s", id), err)
-		} else {
-			logger.Info(fmt.Sprintf("Successfully closed expired auction: %s", id))
-		}
+
+	return expiredAuctionIds
+}
+
+// Atualiza as métricas, grava o vencedor, publica o evento e republica o
+// leilão não vendido depois que o fechamento foi gravado no banco. O gauge
+// de ativos só é decrementado para leilões que estavam no mapa
+func (ar *AuctionRepository) finishExpiredAuction(id, category string, wasTracked bool) {
+	if wasTracked {
+		ar.recordCategoryMetric(category, auction_entity.Active, -1)
 	}
+	ar.recordCategoryMetric(category, auction_entity.Completed, 1)
+	ar.Metrics.IncAuctionsClosed()
+	ar.logger.Info("Successfully closed expired auction",
+		zap.String("auction_id", id),
+		zap.Stringer("status", auction_entity.Completed))
+	ar.recordWinner(id)
+	ar.publishAuctionClosed(id)
+	ar.relistIfUnsold(id)
 }
 
 // Implementação real da atualização de status no banco de dados
-func (ar *AuctionRepository) updateAuctionStatusImpl(id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (ar *AuctionRepository) updateAuctionStatusImpl(
+	ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	ctx, cancel := context.WithTimeout(ctx, ar.opTimeout)
 	defer cancel()
-	
-	filter := bson.M{"_id": id}
+
+	// Só atualiza se o leilão ainda estiver num status que permite a
+	// mudança, para não sobrescrever um fechamento feito por outra operação
+	filter := bson.M{"_id": id, "status": bson.M{"$in": statusesUpdatableTo(status)}}
 	update := bson.M{"$set": bson.M{"status": status}}
-	
-	_, err := ar.Collection.UpdateOne(ctx, filter, update)
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Error updating auction status for id=%s", id), err)
+		ar.logger.Error("Error updating auction status", err,
+			zap.String("auction_id", id),
+			zap.Stringer("status", status))
+		if isConnectivityError(err) {
+			return internal_error.NewServiceUnavailableError("Database unavailable while updating auction status")
+		}
 		return internal_error.NewInternalServerError("Error updating auction status")
 	}
-	
+
+	if result.MatchedCount == 0 {
+		return errStatusChanged(id)
+	}
+
 	return nil
 }
 
+func (ar *AuctionRepository) insertAuctionImpl(ctx context.Context, auction *AuctionEntityMongo) error {
+	ctx, cancel := context.WithTimeout(ctx, ar.opTimeout)
+	defer cancel()
+
+	_, err := ar.Collection.InsertOne(ctx, auction)
+	return err
+}
+
+// Atualiza um leilão e retorna quantos documentos corresponderam ao filtro
+func (ar *AuctionRepository) updateAuctionImpl(ctx context.Context, filter, update bson.M) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, ar.opTimeout)
+	defer cancel()
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.MatchedCount, nil
+}
+
+// Duração usada quando AUCTION_INTERVAL não está definida ou é inválida
+const defaultAuctionDuration = 5 * time.Minute
+
+// Indica se o uso da duração padrão já foi avisado no log
+var auctionDurationFallbackLogged atomic.Bool
+
 // Calcula o intervalo de duração do leilão com base na variável de ambiente
 func getAuctionDuration() time.Duration {
 	auctionInterval := os.Getenv("AUCTION_INTERVAL")
 	duration, err := time.ParseDuration(auctionInterval)
 	if err != nil {
-		return time.Minute * 5 // Valor padrão: 5 minutos
+		// Avisa uma única vez para não poluir o log a cada leilão
+		if auctionDurationFallbackLogged.CompareAndSwap(false, true) {
+			logger.Warn("AUCTION_INTERVAL is unset or invalid, using the default auction duration",
+				zap.String("value", auctionInterval),
+				zap.Duration("duration", defaultAuctionDuration))
+		}
+		return defaultAuctionDuration
 	}
-	
+
 	return duration
 }
 
+// AuctionDuration retorna a duração padrão dos leilões em vigor: a de
+// WithDuration ou, sem ela, AUCTION_INTERVAL (com o valor padrão quando a
+// variável é inválida)
+func (ar *AuctionRepository) AuctionDuration() time.Duration {
+	if ar.auctionDuration > 0 {
+		return ar.auctionDuration
+	}
+	return getAuctionDuration()
+}
+
+// Lê AUCTION_MIN_DESCRIPTION_WORDS; 0 (padrão) desativa a regra
+func getMinDescriptionWords() int {
+	minWords, err := strconv.Atoi(os.Getenv("AUCTION_MIN_DESCRIPTION_WORDS"))
+	if err != nil || minWords < 0 {
+		return 0
+	}
+
+	return minWords
+}
+
+// Lê o orçamento de cada varredura de AUCTION_SWEEP_BUDGET; por padrão
+// usa metade do intervalo de verificação
+func getSweepBudget() time.Duration {
+	budget, err := time.ParseDuration(os.Getenv("AUCTION_SWEEP_BUDGET"))
+	if err != nil || budget < 0 {
+		return getCheckInterval() / 2
+	}
+
+	return budget
+}
+
+// Lê a tolerância de relógio de AUCTION_CLOCK_SKEW_TOLERANCE (padrão: 0).
+// Valores maiores evitam fechamentos prematuros, ao custo de fechar os
+// leilões até esse tempo depois do fim
+func getClockSkewTolerance() time.Duration {
+	tolerance, err := time.ParseDuration(os.Getenv("AUCTION_CLOCK_SKEW_TOLERANCE"))
+	if err != nil || tolerance < 0 {
+		return 0
+	}
+
+	return tolerance
+}
+
+// Menor intervalo aceito entre varreduras, para evitar um loop ocupado
+const minCheckInterval = 100 * time.Millisecond
+
 // Calcula o intervalo de verificação para fechar leilões
+// (AUCTION_CHECK_INTERVAL)
 func getCheckInterval() time.Duration {
-	// Por padrão, verifica a cada 5 segundos
-	return time.Second * 5
+	interval, err := time.ParseDuration(os.Getenv("AUCTION_CHECK_INTERVAL"))
+	if err != nil || interval <= 0 {
+		// Por padrão, verifica a cada 5 segundos
+		return time.Second * 5
+	}
+
+	if interval < minCheckInterval {
+		return minCheckInterval
+	}
+
+	return interval
+}
+
+// Lê AUCTION_CHECK_JITTER, a variação máxima de cada intervalo do monitor
+// (desativada por padrão); é limitada a metade de AUCTION_CHECK_INTERVAL
+func getCheckJitter() time.Duration {
+	jitter, err := time.ParseDuration(os.Getenv("AUCTION_CHECK_JITTER"))
+	if err != nil || jitter < 0 {
+		return 0
+	}
+
+	return jitter
+}
+
+// Lê MONGO_OP_TIMEOUT, o tempo máximo de cada leitura ou escrita no
+// MongoDB; valores inválidos ou não positivos usam o padrão de 5 segundos
+func getOpTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("MONGO_OP_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return 5 * time.Second
+	}
+
+	return timeout
 }
 
+// CreateAuction grava o leilão e o devolve com EndTime preenchido (zero
+// para rascunhos), para que quem chamou possa informar o término
 func (ar *AuctionRepository) CreateAuction(
 	ctx context.Context,
-	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	auctionEntity *auction_entity.Auction) (*auction_entity.Auction, *internal_error.InternalError) {
+	if err := auctionEntity.ValidateDescriptionWords(ar.minDescriptionWords); err != nil {
+		return nil, err
+	}
+
+	// O término é fixado na criação, para que mudanças em AUCTION_INTERVAL
+	// não alterem o prazo de leilões já abertos
+	if auctionEntity.Status == auction_entity.Active {
+		auctionEntity.EndTime = ar.auctionEndTime(auctionEntity)
+	}
+	auctionEntity.ApplyCategoryCase(ar.categoryCase)
+
 	auctionEntityMongo := &AuctionEntityMongo{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   auctionEntity.Condition,
-		Status:      auctionEntity.Status,
-		Timestamp:   auctionEntity.Timestamp.Unix(),
-	}
-	_, err := ar.Collection.InsertOne(ctx, auctionEntityMongo)
-	if err != nil {
-		logger.Error("Error trying to insert auction", err)
-		return internal_error.NewInternalServerError("Error trying to insert auction")
+		Id:                     auctionEntity.Id,
+		SellerId:               auctionEntity.SellerId,
+		IdempotencyKey:         auctionEntity.IdempotencyKey,
+		ProductName:            auctionEntity.ProductName,
+		Category:               auctionEntity.Category,
+		CategoryDisplay:        auctionEntity.CategoryDisplay,
+		Categories:             auctionEntity.Categories,
+		Description:            auctionEntity.Description,
+		Condition:              auctionEntity.Condition,
+		Status:                 auctionEntity.Status,
+		Timestamp:              auctionEntity.Timestamp.Unix(),
+		NotificationTemplateId: auctionEntity.NotificationTemplateId,
+		NotificationMetadata:   auctionEntity.NotificationMetadata,
+		RelistedFrom:           auctionEntity.RelistedFrom,
+		RelistCount:            auctionEntity.RelistCount,
+		BuyNowPrice:            auctionEntity.BuyNowPrice,
+		ReservePrice:           auctionEntity.ReservePrice,
+		StartingBid:            auctionEntity.StartingBid,
+		Duration:               auctionEntity.Duration,
+	}
+	if !auctionEntity.EndTime.IsZero() {
+		auctionEntityMongo.EndTime = auctionEntity.EndTime.Unix()
 	}
-	
+	if !auctionEntity.StartTime.IsZero() {
+		auctionEntityMongo.StartTime = auctionEntity.StartTime.Unix()
+	}
+	if err := ar.insertAuction(ctx, auctionEntityMongo); err != nil {
+		// Criação repetida com a mesma chave: devolve o leilão já criado
+		if auctionEntity.IdempotencyKey != "" && mongo.IsDuplicateKeyError(err) {
+			return ar.findAuctionByIdempotencyKey(ctx, auctionEntity.SellerId, auctionEntity.IdempotencyKey)
+		}
+
+		ar.logger.Error("Error trying to insert auction", err)
+		return nil, internal_error.NewInternalServerError("Error trying to insert auction")
+	}
+	ar.Metrics.IncAuctionsCreated()
+
+	// Rascunhos só passam a ser monitorados quando publicados, e leilões
+	// agendados quando o monitor os inicia
+	if auctionEntity.Status != auction_entity.Active {
+		ar.logger.Info("Auction created",
+			zap.String("auction_id", auctionEntity.Id),
+			zap.Stringer("status", auctionEntity.Status))
+		return auctionEntity, nil
+	}
+
 	// Adiciona o leilão ao mapa de leilões ativos com seu tempo de expiração
-	endTime := auctionEntity.Timestamp.Add(getAuctionDuration())
-	
-	ar.activeAuctionsMutex.Lock()
-	ar.activeAuctions[auctionEntity.Id] = endTime
-	ar.activeAuctionsMutex.Unlock()
-	
-	logger.Info(fmt.Sprintf("Auction created with ID: %s, will expire at: %s", 
-		auctionEntity.Id, endTime.Format(time.RFC3339)))
-	
-	return nil
+	endTime := auctionEntity.EndTime
+	ar.trackAuction(auctionEntity.Id, endTime, auctionEntity.Category)
+	ar.Metrics.SetActiveAuctions(ar.ActiveAuctionCount())
+
+	ar.logger.Info("Auction created",
+		zap.String("auction_id", auctionEntity.Id),
+		zap.Stringer("status", auctionEntity.Status),
+		zap.Time("end_time", endTime))
+
+	return auctionEntity, nil
 }
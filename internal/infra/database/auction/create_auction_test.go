@@ -0,0 +1,458 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/configuration/metrics"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCheckExpiredAuctionsSplitsWorkAcrossTicks(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.sweepBudget = 10 * time.Millisecond
+	repo.batchCloseSize = 5
+
+	var closedMutex sync.Mutex
+	closed := make(map[string]int)
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		time.Sleep(2 * time.Millisecond)
+		closedMutex.Lock()
+		closed[id]++
+		closedMutex.Unlock()
+		return nil
+	}
+
+	const total = 30
+	for i := 0; i < total; i++ {
+		repo.activeAuctions[fmt.Sprintf("auction-%d", i)] = time.Now().Add(-time.Second)
+	}
+
+	repo.checkExpiredAuctions()
+
+	if remaining := len(repo.activeAuctions); remaining == 0 || remaining == total {
+		t.Fatalf("Expected the first sweep to process only part of the work, %d remaining", remaining)
+	}
+
+	sweeps := 1
+	for len(repo.activeAuctions) > 0 && sweeps < total {
+		repo.checkExpiredAuctions()
+		sweeps++
+	}
+
+	if len(repo.activeAuctions) != 0 {
+		t.Fatalf("Expected all auctions to be processed, %d remaining", len(repo.activeAuctions))
+	}
+
+	if len(closed) != total {
+		t.Errorf("Expected %d auctions to be closed, got %d", total, len(closed))
+	}
+
+	for id, count := range closed {
+		if count != 1 {
+			t.Errorf("Expected auction %s to be closed once, got %d", id, count)
+		}
+	}
+}
+
+func TestGetSweepBudget(t *testing.T) {
+	os.Setenv("AUCTION_SWEEP_BUDGET", "750ms")
+	if budget := getSweepBudget(); budget != 750*time.Millisecond {
+		t.Errorf("Expected budget 750ms, got %s", budget)
+	}
+
+	os.Setenv("AUCTION_SWEEP_BUDGET", "invalid")
+	if budget := getSweepBudget(); budget != getCheckInterval()/2 {
+		t.Errorf("Expected default budget %s, got %s", getCheckInterval()/2, budget)
+	}
+
+	os.Unsetenv("AUCTION_SWEEP_BUDGET")
+}
+
+func TestGetCheckInterval(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"250ms", 250 * time.Millisecond},
+		{"invalid", 5 * time.Second},
+		{"", 5 * time.Second},
+		{"0s", 5 * time.Second},
+		{"-1s", 5 * time.Second},
+		{"1ms", minCheckInterval},
+	}
+
+	for _, tc := range testCases {
+		os.Setenv("AUCTION_CHECK_INTERVAL", tc.value)
+		if got := getCheckInterval(); got != tc.expected {
+			t.Errorf("For %q expected %s, got %s", tc.value, tc.expected, got)
+		}
+	}
+	os.Unsetenv("AUCTION_CHECK_INTERVAL")
+}
+
+func TestNextCheckDelayVariesWithinJitter(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.checkInterval = 5 * time.Second
+	repo.checkJitter = time.Second
+
+	delays := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		delay := repo.nextCheckDelay()
+		if delay < 4*time.Second || delay > 6*time.Second {
+			t.Fatalf("Expected delay within 5s ± 1s, got %s", delay)
+		}
+		delays[delay] = true
+	}
+
+	if len(delays) < 2 {
+		t.Errorf("Expected successive delays to vary, got %v", delays)
+	}
+
+	// A variação é limitada a metade do intervalo
+	repo.checkJitter = time.Minute
+	for i := 0; i < 100; i++ {
+		if delay := repo.nextCheckDelay(); delay < 2500*time.Millisecond || delay > 7500*time.Millisecond {
+			t.Fatalf("Expected jitter to be capped at half the interval, got %s", delay)
+		}
+	}
+
+	repo.checkJitter = 0
+	if delay := repo.nextCheckDelay(); delay != 5*time.Second {
+		t.Errorf("Expected a fixed delay without jitter, got %s", delay)
+	}
+}
+
+func TestGetOpTimeout(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"30s", 30 * time.Second},
+		{"1500ms", 1500 * time.Millisecond},
+		{"invalid", 5 * time.Second},
+		{"", 5 * time.Second},
+		{"0s", 5 * time.Second},
+		{"-1s", 5 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		os.Setenv("MONGO_OP_TIMEOUT", tc.value)
+		if got := getOpTimeout(); got != tc.expected {
+			t.Errorf("For %q expected %s, got %s", tc.value, tc.expected, got)
+		}
+	}
+	os.Unsetenv("MONGO_OP_TIMEOUT")
+}
+
+func TestCreateAuctionAppliesConfiguredCategoryCase(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.categoryCase = auction_entity.CategoryTitleCase
+
+	var inserted *AuctionEntityMongo
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		inserted = auction
+		return nil
+	}
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "home and garden", "A valid description", auction_entity.New)
+	if _, err := repo.CreateAuction(context.Background(), auction); err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	if inserted.Category != "Home And Garden" {
+		t.Errorf("Expected the category stored in title case, got %q", inserted.Category)
+	}
+
+	filter := buildFindAuctionsFilter(nil, []string{"home AND garden"}, "", "", repo.categoryCase)
+	expected := bson.M{"category": bson.M{"$in": []string{"Home And Garden"}}}
+	if alternatives := filter["$or"].(bson.A); !reflect.DeepEqual(alternatives[0], expected) {
+		t.Errorf("Expected searches to use the stored case, got %v", alternatives[0])
+	}
+}
+
+func TestCreateAuctionRequiresConfiguredDescriptionWords(t *testing.T) {
+	os.Setenv("AUCTION_MIN_DESCRIPTION_WORDS", "3")
+	defer os.Unsetenv("AUCTION_MIN_DESCRIPTION_WORDS")
+
+	repo := setupInMemoryRepository()
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		t.Fatalf("Expected the auction not to be stored")
+		return nil
+	}
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "Aaaaaaaaaaaaaaaa", auction_entity.New)
+	if _, err := repo.CreateAuction(context.Background(), auction); err == nil || err.Code != "description.too_few_words" {
+		t.Errorf("Expected description.too_few_words, got %v", err)
+	}
+}
+
+func TestCreateAuctionReturnsEndTime(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "10m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := setupInMemoryRepository()
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error { return nil }
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	created, err := repo.CreateAuction(context.Background(), auction)
+	if err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	expected := auction.Timestamp.Add(10 * time.Minute)
+	if !created.EndTime.Equal(expected) || !repo.activeAuctions[auction.Id].Equal(expected) {
+		t.Errorf("Expected returned and tracked end time %v, got %v and %v",
+			expected, created.EndTime, repo.activeAuctions[auction.Id])
+	}
+
+	draft, _ := auction_entity.CreateDraftAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	if created, err := repo.CreateAuction(context.Background(), draft); err != nil || !created.EndTime.IsZero() {
+		t.Errorf("Expected draft to be created without end time, got %+v (%v)", created, err)
+	}
+}
+
+func TestCreateAuctionUsesCustomDuration(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "1h")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := setupInMemoryRepository()
+	var stored *AuctionEntityMongo
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		stored = auction
+		return nil
+	}
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	auction.SetDuration(5 * time.Minute)
+
+	created, err := repo.CreateAuction(context.Background(), auction)
+	if err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	expected := auction.Timestamp.Add(5 * time.Minute)
+	if !created.EndTime.Equal(expected) || stored.Duration != 5*time.Minute {
+		t.Errorf("Expected custom end time %v and stored duration, got %v and %v",
+			expected, created.EndTime, stored.Duration)
+	}
+}
+
+func TestAuctionDurationFallbackIsLoggedOnce(t *testing.T) {
+	defer os.Unsetenv("AUCTION_INTERVAL")
+	repo := setupInMemoryRepository()
+
+	testCases := []struct {
+		value    string
+		expected time.Duration
+		warnings int
+	}{
+		{"10m", 10 * time.Minute, 0},
+		{"invalid", 5 * time.Minute, 1},
+	}
+
+	for _, tc := range testCases {
+		core, logs := observer.New(zap.WarnLevel)
+		restore := logger.Replace(zap.New(core))
+		auctionDurationFallbackLogged.Store(false)
+		os.Setenv("AUCTION_INTERVAL", tc.value)
+
+		// Várias leituras devem gerar no máximo um aviso
+		for i := 0; i < 3; i++ {
+			if got := repo.AuctionDuration(); got != tc.expected {
+				t.Errorf("For %q expected %s, got %s", tc.value, tc.expected, got)
+			}
+		}
+		restore()
+
+		warnings := logs.FilterMessageSnippet("AUCTION_INTERVAL").Len()
+		if warnings != tc.warnings {
+			t.Errorf("For %q expected %d warnings, got %d", tc.value, tc.warnings, warnings)
+		}
+	}
+}
+
+func TestCreateAuctionLogsStructuredFields(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	defer logger.Replace(zap.New(core))()
+
+	repo := setupInMemoryRepository()
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error { return nil }
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	created, err := repo.CreateAuction(context.Background(), auction)
+	if err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	entries := logs.FilterMessage("Auction created").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one creation log entry, got %d", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields["auction_id"] != auction.Id || fields["status"] != "Active" {
+		t.Errorf("Expected auction_id and status fields, got %v", fields)
+	}
+
+	if endTime, ok := fields["end_time"].(time.Time); !ok || !endTime.Equal(created.EndTime) {
+		t.Errorf("Expected end_time field %v, got %v", created.EndTime, fields["end_time"])
+	}
+}
+
+func TestWithLoggerReceivesRepositoryLogs(t *testing.T) {
+	globalCore, globalLogs := observer.New(zap.InfoLevel)
+	defer logger.Replace(zap.New(globalCore))()
+
+	database := setupDisconnectedDatabase(t, "logger_test")
+	first := NewAuctionRepository(database)
+	defer first.Close()
+
+	// O segundo repositório não inicia o monitor e registra o aviso no
+	// logger injetado
+	core, logs := observer.New(zap.InfoLevel)
+	repo := NewAuctionRepository(database, WithLogger(zap.New(core)))
+	defer repo.Close()
+
+	if logs.FilterMessageSnippet("Auction monitor already running").Len() != 1 {
+		t.Errorf("Expected the monitor warning on the injected logger, got %v", logs.All())
+	}
+
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error { return nil }
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	if _, err := repo.CreateAuction(context.Background(), auction); err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	if logs.FilterMessage("Auction created").Len() != 1 {
+		t.Errorf("Expected the creation log on the injected logger, got %v", logs.All())
+	}
+
+	if globalLogs.FilterMessage("Auction created").Len() != 0 ||
+		globalLogs.FilterMessageSnippet("Auction monitor already running").Len() != 0 {
+		t.Errorf("Expected no repository logs on the global logger, got %v", globalLogs.All())
+	}
+}
+
+func TestWithoutMonitorSkipsMonitorGoroutine(t *testing.T) {
+	database := setupDisconnectedDatabase(t, "without_monitor_test")
+	repo := NewAuctionRepository(database, WithoutMonitor())
+	defer repo.Close()
+
+	if repo.monitorStarted {
+		t.Errorf("Expected no monitor to be started")
+	}
+
+	// Sem monitor, a coleção continua livre para outro repositório
+	other := NewAuctionRepository(database)
+	defer other.Close()
+	if !other.monitorStarted {
+		t.Errorf("Expected the monitor registration to be left free")
+	}
+}
+
+func TestWithCheckIntervalDrivesMonitor(t *testing.T) {
+	os.Unsetenv("AUCTION_CHECK_INTERVAL")
+
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "check_interval_test"),
+		WithCheckInterval(100*time.Millisecond))
+	defer repo.Close()
+
+	if repo.checkInterval != 100*time.Millisecond || repo.sweepBudget != 50*time.Millisecond {
+		t.Errorf("Expected the interval and its default budget to follow the option, got %v and %v",
+			repo.checkInterval, repo.sweepBudget)
+	}
+
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return nil
+	}
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+
+	deadline := time.Now().Add(time.Second)
+	for repo.isTracked("expired") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if repo.isTracked("expired") {
+		t.Errorf("Expected the monitor to sweep at the configured interval")
+	}
+}
+
+func TestWithDurationSetsEndTime(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "1m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "duration_test"),
+		WithoutMonitor(), WithDuration(time.Hour))
+	defer repo.Close()
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error { return nil }
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	created, err := repo.CreateAuction(context.Background(), auction)
+	if err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	if repo.AuctionDuration() != time.Hour || !created.EndTime.Equal(created.Timestamp.Add(time.Hour)) {
+		t.Errorf("Expected the option to override AUCTION_INTERVAL, got end time %v for %v",
+			created.EndTime, created.Timestamp)
+	}
+}
+
+func TestWithClockDecidesExpiration(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "clock_test"),
+		WithoutMonitor(), WithClock(newFakeClock(future)))
+	defer repo.Close()
+
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return nil
+	}
+	repo.trackAuction("running", time.Now().Add(time.Minute), "books")
+
+	repo.checkExpiredAuctions()
+
+	if repo.isTracked("running") {
+		t.Errorf("Expected the injected clock to decide the auction has expired")
+	}
+}
+
+func TestWithMetricsInstallsMetricsBeforeStart(t *testing.T) {
+	auctionMetrics := metrics.NewPrometheusAuctionMetrics()
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "metrics_option_test"),
+		WithoutMonitor(), WithMetrics(auctionMetrics))
+	defer repo.Close()
+
+	if repo.Metrics != auctionMetrics {
+		t.Errorf("Expected the option to install the metrics, got %v", repo.Metrics)
+	}
+
+	// nil mantém o padrão
+	repo = NewAuctionRepository(setupDisconnectedDatabase(t, "metrics_option_test"), WithoutMonitor(), WithMetrics(nil))
+	defer repo.Close()
+	if repo.Metrics == nil {
+		t.Errorf("Expected nil metrics to keep the default")
+	}
+}
+
+func TestNewAuctionRepositoryDefaultsWithoutOptions(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "7m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "defaults_test"))
+	defer repo.Close()
+
+	if !repo.monitorStarted || repo.checkInterval != getCheckInterval() || repo.AuctionDuration() != 7*time.Minute {
+		t.Errorf("Expected the environment defaults without options, got monitor %v, interval %v, duration %v",
+			repo.monitorStarted, repo.checkInterval, repo.AuctionDuration())
+	}
+}
@@ -0,0 +1,86 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// DeleteAuction remove o leilão, todos os seus lances e o acompanhamento
+// pelo monitor
+func (ar *AuctionRepository) DeleteAuction(ctx context.Context, id string) *internal_error.InternalError {
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ar.opTimeout)
+	defer cancel()
+
+	// A remoção e a saída do mapa acontecem na mesma transição, para que o
+	// monitor não feche um leilão que está sendo apagado. Os lances são
+	// apagados antes do leilão, para que uma falha no meio não deixe lances
+	// de um leilão que não existe mais
+	category := auctionEntity.Category
+	status := auctionEntity.Status
+	var wasTracked bool
+	var bidsDeleted int64
+	deleted, err := ar.transitionWith(ctx, id, nil,
+		func(ctx context.Context) (bool, *internal_error.InternalError) {
+			bids, deleteErr := ar.Collection.Database().Collection("bids").
+				DeleteMany(ctx, bson.M{"auction_id": id})
+			if deleteErr != nil {
+				ar.logger.Error("Error trying to delete auction bids", deleteErr, zap.String("auction_id", id))
+				return false, internal_error.NewInternalServerError("Error trying to delete auction bids")
+			}
+			bidsDeleted = bids.DeletedCount
+
+			// O status gravado no momento da remoção decide qual série do
+			// gauge é decrementada
+			var deletedAuction AuctionEntityMongo
+			deleteErr = ar.Collection.FindOneAndDelete(ctx, bson.M{"_id": id}).Decode(&deletedAuction)
+			if errors.Is(deleteErr, mongo.ErrNoDocuments) {
+				return false, nil
+			}
+			if deleteErr != nil {
+				ar.logger.Error("Error trying to delete auction", deleteErr, zap.String("auction_id", id))
+				return false, internal_error.NewInternalServerError("Error trying to delete auction")
+			}
+			status, category = deletedAuction.Status, deletedAuction.Category
+			return true, nil
+		},
+		func() {
+			if trackedCategory, tracked := ar.untrackLocked(id); tracked {
+				category, wasTracked = trackedCategory, true
+			}
+		})
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+
+	// Ativos só entram no gauge enquanto acompanhados pelo monitor;
+	// concluídos, pausados e cancelados entram ao mudar de status.
+	// Rascunhos e agendados não são contados
+	switch status {
+	case auction_entity.Active:
+		if wasTracked {
+			ar.recordCategoryMetric(category, auction_entity.Active, -1)
+		}
+	case auction_entity.Completed, auction_entity.Paused, auction_entity.Cancelled:
+		ar.recordCategoryMetric(category, status, -1)
+	}
+
+	ar.logger.Info("Auction deleted",
+		zap.String("auction_id", id),
+		zap.Int64("bids", bidsDeleted))
+	return nil
+}
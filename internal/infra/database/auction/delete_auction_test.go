@@ -0,0 +1,97 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDeleteAuctionRemovesAuctionAndBids(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	seed := AuctionEntityMongo{Id: "deleted-auction", Category: "books", Condition: auction_entity.New,
+		Status: auction_entity.Active, Timestamp: time.Now().Unix()}
+	if _, err := repo.Collection.InsertOne(ctx, seed); err != nil {
+		t.Fatalf("Failed to seed auction: %v", err)
+	}
+	repo.trackAuction(seed.Id, time.Now().Add(time.Hour), seed.Category)
+
+	bids := database.Collection("bids")
+	if _, err := bids.InsertMany(ctx, []interface{}{
+		bson.M{"_id": "bid-1", "auction_id": seed.Id, "amount": 10.0},
+		bson.M{"_id": "bid-2", "auction_id": seed.Id, "amount": 20.0},
+		bson.M{"_id": "other-bid", "auction_id": "other-auction", "amount": 30.0},
+	}); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	if err := repo.DeleteAuction(ctx, seed.Id); err != nil {
+		t.Fatalf("Failed to delete auction: %v", err)
+	}
+
+	if count, _ := repo.Collection.CountDocuments(ctx, bson.M{"_id": seed.Id}); count != 0 {
+		t.Errorf("Expected the auction to be deleted, found %d", count)
+	}
+
+	if count, _ := bids.CountDocuments(ctx, bson.M{"auction_id": seed.Id}); count != 0 {
+		t.Errorf("Expected the auction bids to be deleted, found %d", count)
+	}
+
+	if count, _ := bids.CountDocuments(ctx, bson.M{"auction_id": "other-auction"}); count != 1 {
+		t.Errorf("Expected bids of other auctions to be kept, found %d", count)
+	}
+
+	if repo.isTracked(seed.Id) {
+		t.Errorf("Expected the deleted auction to leave active tracking")
+	}
+
+	if active := repo.categoryGauge.Value("books", "active"); active != 0 {
+		t.Errorf("Expected active gauge back at 0, got %v", active)
+	}
+
+	// Rascunhos nunca entraram no gauge e não o deixam negativo
+	draft := AuctionEntityMongo{Id: "deleted-draft", Category: "books", Condition: auction_entity.New,
+		Status: auction_entity.Draft, Timestamp: time.Now().Unix()}
+	if _, err := repo.Collection.InsertOne(ctx, draft); err != nil {
+		t.Fatalf("Failed to seed draft: %v", err)
+	}
+	if err := repo.DeleteAuction(ctx, draft.Id); err != nil {
+		t.Fatalf("Failed to delete draft: %v", err)
+	}
+	if drafts := repo.categoryGauge.Value("books", "draft"); drafts != 0 {
+		t.Errorf("Expected draft gauge untouched, got %v", drafts)
+	}
+
+	// Concluídos foram contados no fechamento e saem do gauge
+	completed := AuctionEntityMongo{Id: "deleted-completed", Category: "books", Condition: auction_entity.New,
+		Status: auction_entity.Completed, Timestamp: time.Now().Unix()}
+	if _, err := repo.Collection.InsertOne(ctx, completed); err != nil {
+		t.Fatalf("Failed to seed completed auction: %v", err)
+	}
+	repo.recordCategoryMetric("books", auction_entity.Completed, 1)
+	if err := repo.DeleteAuction(ctx, completed.Id); err != nil {
+		t.Fatalf("Failed to delete completed auction: %v", err)
+	}
+	if value := repo.categoryGauge.Value("books", "completed"); value != 0 {
+		t.Errorf("Expected completed gauge back at 0, got %v", value)
+	}
+}
+
+func TestDeleteAuctionNotFound(t *testing.T) {
+	database := setupMongoDatabase(t)
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	err := repo.DeleteAuction(context.Background(), "missing")
+	if err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
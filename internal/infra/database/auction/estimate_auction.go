@@ -0,0 +1,145 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PriceEstimate struct {
+	Amount      float64 `json:"amount"`
+	Comparables int64   `json:"comparables"`
+}
+
+// EstimatePrice sugere um preço com a média dos lances vencedores de
+// leilões encerrados da mesma categoria com nome parecido. O vencedor de
+// cada leilão é escolhido na moeda base, como em recordWinner. Sem comparáveis
+// suficientes (AUCTION_ESTIMATE_MIN_COMPARABLES, padrão 3) retorna NotFound
+// com o código "estimate.insufficient_data"
+func (ar *AuctionRepository) EstimatePrice(
+	ctx context.Context, productName, category string) (*PriceEstimate, *internal_error.InternalError) {
+	pattern := productNamePattern(productName)
+	if pattern == "" {
+		return nil, internal_error.NewBadRequestError("product name has no searchable words").
+			WithCode("product_name.too_short")
+	}
+
+	cursor, err := ar.Collection.Aggregate(ctx, estimatePricePipeline(pattern, auction_entity.NormalizeCategory(category, ar.categoryCase)))
+	if err != nil {
+		ar.logger.Error("Error trying to estimate auction price", err)
+		return nil, internal_error.NewInternalServerError("Error trying to estimate auction price")
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Bids []struct {
+			Amount   float64 `bson:"amount"`
+			Currency string  `bson:"currency"`
+		} `bson:"bids"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		ar.logger.Error("Error trying to decode auction price estimate", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode auction price estimate")
+	}
+
+	bidsByAuction := make([][]bid_entity.Bid, len(results))
+	for i, result := range results {
+		for _, bid := range result.Bids {
+			bidsByAuction[i] = append(bidsByAuction[i], bid_entity.Bid{Amount: bid.Amount, Currency: bid.Currency})
+		}
+	}
+
+	average, comparables := averageWinningBid(bidsByAuction, ar.amountInBaseCurrency)
+	return newPriceEstimate(average, comparables, getMinComparables())
+}
+
+// Média, na moeda base, do maior lance de cada leilão; leilões sem lances
+// conversíveis não contam como comparáveis
+func averageWinningBid(
+	bidsByAuction [][]bid_entity.Bid, amountIn func(bid bid_entity.Bid) (float64, bool)) (float64, int64) {
+	var total float64
+	var comparables int64
+	for _, bids := range bidsByAuction {
+		var highest float64
+		found := false
+		for _, bid := range bids {
+			amount, ok := amountIn(bid)
+			if ok && (!found || amount > highest) {
+				highest, found = amount, true
+			}
+		}
+		if found {
+			total += highest
+			comparables++
+		}
+	}
+
+	if comparables == 0 {
+		return 0, 0
+	}
+	return total / float64(comparables), comparables
+}
+
+// Casa qualquer palavra do nome com 3 ou mais caracteres, sem diferenciar
+// maiúsculas ("iPhone 12 Pro" encontra "Iphone 13")
+func productNamePattern(productName string) string {
+	var words []string
+	for _, word := range strings.Fields(productName) {
+		if len([]rune(word)) >= 3 {
+			words = append(words, regexp.QuoteMeta(word))
+		}
+	}
+
+	return strings.Join(words, "|")
+}
+
+func estimatePricePipeline(pattern, category string) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"status":       auction_entity.Completed,
+			"category":     category,
+			"product_name": primitive.Regex{Pattern: pattern, Options: "i"},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "bids",
+			"let":  bson.M{"auctionId": "$_id"},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$auction_id", "$$auctionId"}}}}},
+				{{Key: "$project", Value: bson.M{"_id": 0, "amount": 1, "currency": 1}}},
+			},
+			"as": "bids",
+		}}},
+		// Lances em moedas diferentes são comparados fora do banco
+		{{Key: "$match", Value: bson.M{"bids.0": bson.M{"$exists": true}}}},
+		{{Key: "$project", Value: bson.M{"bids": 1}}},
+	}
+}
+
+func newPriceEstimate(average float64, comparables int64, minComparables int64) (*PriceEstimate, *internal_error.InternalError) {
+	if comparables < minComparables {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Not enough comparable auctions to estimate a price (%d of %d)", comparables, minComparables)).
+			WithCode("estimate.insufficient_data")
+	}
+
+	return &PriceEstimate{Amount: average, Comparables: comparables}, nil
+}
+
+func getMinComparables() int64 {
+	value, err := strconv.ParseInt(os.Getenv("AUCTION_ESTIMATE_MIN_COMPARABLES"), 10, 64)
+	if err != nil || value < 1 {
+		return 3
+	}
+
+	return value
+}
@@ -0,0 +1,100 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"regexp"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestProductNamePattern(t *testing.T) {
+	pattern := productNamePattern("iPhone 12 Pro (used)")
+	if pattern != `iPhone|Pro|\(used\)` {
+		t.Fatalf("Unexpected pattern %q", pattern)
+	}
+
+	matcher := regexp.MustCompile("(?i)" + pattern)
+	if !matcher.MatchString("IPHONE 13") || matcher.MatchString("Galaxy S21") {
+		t.Errorf("Expected pattern to match similar names only")
+	}
+
+	if productNamePattern("TV 4k") != "" {
+		t.Errorf("Expected short words to be ignored")
+	}
+}
+
+func TestNewPriceEstimate(t *testing.T) {
+	if _, err := newPriceEstimate(100, 2, 3); err == nil || err.Code != "estimate.insufficient_data" {
+		t.Errorf("Expected insufficient data with 2 of 3 comparables, got %v", err)
+	}
+
+	estimate, err := newPriceEstimate(150, 3, 3)
+	if err != nil || estimate.Amount != 150 || estimate.Comparables != 3 {
+		t.Errorf("Expected an estimate of 150 over 3 comparables, got %+v, %v", estimate, err)
+	}
+}
+
+func TestAverageWinningBidInBaseCurrency(t *testing.T) {
+	finder := fakeWinningBidFinder{}
+	bidsByAuction := [][]bid_entity.Bid{
+		// 100 USD valem 500 e vencem o lance de 300 na moeda base
+		{{Amount: 300}, {Amount: 100, Currency: "USD"}},
+		{{Amount: 200}},
+		// Sem lances conversíveis o leilão não conta
+		{{Amount: 1000, Currency: "EUR"}},
+	}
+
+	average, comparables := averageWinningBid(bidsByAuction, finder.AmountInBaseCurrency)
+	if comparables != 2 || average != 350 {
+		t.Errorf("Expected 350 over 2 comparables, got %v over %d", average, comparables)
+	}
+}
+
+func TestEstimatePrice(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	auctions := []interface{}{
+		AuctionEntityMongo{Id: "1", ProductName: "iPhone 11", Category: "phones", Status: auction_entity.Completed},
+		AuctionEntityMongo{Id: "2", ProductName: "Apple iPhone 12", Category: "phones", Status: auction_entity.Completed},
+		AuctionEntityMongo{Id: "3", ProductName: "IPHONE 13 Pro", Category: "phones", Status: auction_entity.Completed},
+		AuctionEntityMongo{Id: "4", ProductName: "iPhone case", Category: "accessories", Status: auction_entity.Completed},
+		AuctionEntityMongo{Id: "5", ProductName: "iPhone 14", Category: "phones", Status: auction_entity.Active},
+		AuctionEntityMongo{Id: "6", ProductName: "Galaxy S21", Category: "phones", Status: auction_entity.Completed},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	bids := []interface{}{
+		bson.M{"_id": "b1", "auction_id": "1", "amount": 100.0},
+		bson.M{"_id": "b2", "auction_id": "1", "amount": 200.0},
+		bson.M{"_id": "b3", "auction_id": "2", "amount": 300.0},
+		bson.M{"_id": "b4", "auction_id": "3", "amount": 400.0},
+		bson.M{"_id": "b5", "auction_id": "4", "amount": 20.0},
+		bson.M{"_id": "b6", "auction_id": "5", "amount": 900.0},
+		bson.M{"_id": "b7", "auction_id": "6", "amount": 800.0},
+	}
+	if _, err := database.Collection("bids").InsertMany(ctx, bids); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	estimate, err := repo.EstimatePrice(ctx, "iphone", "Phones")
+	if err != nil {
+		t.Fatalf("Expected an estimate, got %v", err)
+	}
+
+	if estimate.Comparables != 3 || estimate.Amount != 300 {
+		t.Errorf("Expected 300 over 3 comparables, got %+v", estimate)
+	}
+
+	if _, err := repo.EstimatePrice(ctx, "Pixel 7", "phones"); err == nil || err.Code != "estimate.insufficient_data" {
+		t.Errorf("Expected insufficient data for an unseen product, got %v", err)
+	}
+}
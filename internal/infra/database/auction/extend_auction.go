@@ -0,0 +1,151 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// ExtendAuction prorroga manualmente o término de um leilão ativo (ex.:
+// após uma indisponibilidade), gravando o novo end_time no banco e
+// atualizando o mapa na mesma transição, para que o monitor não feche o
+// leilão no meio da prorrogação
+func (ar *AuctionRepository) ExtendAuction(
+	ctx context.Context, id string, extra time.Duration) (time.Time, *internal_error.InternalError) {
+	if extra <= 0 {
+		return time.Time{}, internal_error.NewBadRequestError("extension must be greater than zero").
+			WithCode("extension.invalid")
+	}
+
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	notActive := internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s is not active", id)).WithCode("auction.not_active")
+	if auctionEntity.Status != auction_entity.Active {
+		return time.Time{}, notActive
+	}
+
+	var endTime time.Time
+	var tracked bool
+	applied, err := ar.transitionWith(ctx, id,
+		func() bool {
+			// Fora do mapa o leilão já está sendo fechado ou está pausado; na
+			// varredura pelo banco o mapa não é usado
+			endTime, tracked = ar.activeAuctions[id]
+			if !tracked {
+				if ar.sweepStrategy != SweepDatabase {
+					return false
+				}
+				endTime = ar.auctionEndTime(auctionEntity)
+			}
+			endTime = endTime.Add(extra)
+			return true
+		},
+		func(ctx context.Context) (bool, *internal_error.InternalError) {
+			filter := bson.M{"_id": id, "status": auction_entity.Active}
+			update := bson.M{"$set": bson.M{"end_time": endTime.Unix()}}
+			matched, updateErr := ar.updateAuction(ctx, filter, update)
+			if updateErr != nil {
+				ar.logger.Error("Error trying to extend auction", updateErr, zap.String("auction_id", id))
+				return false, internal_error.NewInternalServerError("Error trying to extend auction")
+			}
+			return matched > 0, nil
+		},
+		func() {
+			if tracked {
+				ar.activeAuctions[id] = endTime
+			}
+		})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !applied {
+		return time.Time{}, notActive
+	}
+
+	ar.logger.Info("Auction extended",
+		zap.String("auction_id", id),
+		zap.Time("end_time", endTime))
+	return endTime, nil
+}
+
+// ExtendIfClosing prorroga o fim de um leilão em andamento quando um lance
+// chega dentro da janela final (AUCTION_EXTENSION_WINDOW), evitando lances
+// de última hora. O novo end_time é gravado no banco e o mapa atualizado na
+// mesma transição, como em ExtendAuction. Retorna o novo término e se houve
+// prorrogação
+func (ar *AuctionRepository) ExtendIfClosing(ctx context.Context, auctionId string) (time.Time, bool) {
+	if ar.extensionWindow <= 0 || ar.extensionDuration <= 0 {
+		return time.Time{}, false
+	}
+
+	var endTime time.Time
+	extended, err := ar.transitionWith(ctx, auctionId,
+		func() bool {
+			var tracked bool
+			if endTime, tracked = ar.activeAuctions[auctionId]; !tracked {
+				return false
+			}
+
+			now := ar.now()
+			if !now.Before(endTime) || endTime.Sub(now) > ar.extensionWindow {
+				return false
+			}
+			endTime = endTime.Add(ar.extensionDuration)
+			return true
+		},
+		func(ctx context.Context) (bool, *internal_error.InternalError) {
+			filter := bson.M{"_id": auctionId, "status": auction_entity.Active}
+			update := bson.M{"$set": bson.M{"end_time": endTime.Unix()}}
+			matched, updateErr := ar.updateAuction(ctx, filter, update)
+			if updateErr != nil {
+				ar.logger.Error("Error trying to extend auction after a late bid", updateErr,
+					zap.String("auction_id", auctionId))
+				return false, internal_error.NewInternalServerError("Error trying to extend auction")
+			}
+			return matched > 0, nil
+		},
+		func() {
+			ar.activeAuctions[auctionId] = endTime
+		})
+	// Um leilão reservado por outra transição (ex.: sendo pausado) não é
+	// prorrogado
+	if err != nil || !extended {
+		return time.Time{}, false
+	}
+
+	ar.logger.Info("Auction extended after a late bid",
+		zap.String("auction_id", auctionId),
+		zap.Time("end_time", endTime))
+	return endTime, true
+}
+
+// Janela final em que um lance prorroga o leilão (AUCTION_EXTENSION_WINDOW,
+// ex.: 30s); 0 (padrão) desativa a prorrogação
+func getExtensionWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv("AUCTION_EXTENSION_WINDOW"))
+	if err != nil || window < 0 {
+		return 0
+	}
+
+	return window
+}
+
+// Tempo acrescentado a cada prorrogação (AUCTION_EXTENSION_DURATION)
+func getExtensionDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("AUCTION_EXTENSION_DURATION"))
+	if err != nil || duration < 0 {
+		return 0
+	}
+
+	return duration
+}
@@ -0,0 +1,148 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestExtendAuction(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	stubFindAuctionById(repo, auction)
+
+	deadline := time.Now().Add(time.Minute).Truncate(time.Second)
+	repo.trackAuction(auction.Id, deadline, "electronics")
+
+	var updates []bson.M
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		updates = append(updates, update)
+		return 1, nil
+	}
+
+	endTime, err := repo.ExtendAuction(context.Background(), auction.Id, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to extend auction: %v", err)
+	}
+
+	expected := deadline.Add(10 * time.Minute)
+	if !endTime.Equal(expected) {
+		t.Errorf("Expected new end time %v, got %v", expected, endTime)
+	}
+
+	if tracked := repo.activeAuctions[auction.Id]; !tracked.Equal(expected) {
+		t.Errorf("Expected tracked end time %v, got %v", expected, tracked)
+	}
+
+	if len(updates) != 1 || updates[0]["$set"].(bson.M)["end_time"] != expected.Unix() {
+		t.Errorf("Expected end_time %d to be persisted, got %v", expected.Unix(), updates)
+	}
+}
+
+func TestExtendAuctionRejectsClosedAuction(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	closed, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	closed.Status = auction_entity.Completed
+	stubFindAuctionById(repo, closed)
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		t.Fatalf("Expected no update for a closed auction")
+		return 0, nil
+	}
+
+	_, err := repo.ExtendAuction(context.Background(), closed.Id, time.Minute)
+	if err == nil || err.Code != "auction.not_active" {
+		t.Errorf("Expected auction.not_active for a closed auction, got %v", err)
+	}
+
+	if _, err := repo.ExtendAuction(context.Background(), "missing", time.Minute); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for an unknown auction, got %v", err)
+	}
+
+	if _, err := repo.ExtendAuction(context.Background(), closed.Id, 0); err == nil || err.Code != "extension.invalid" {
+		t.Errorf("Expected extension.invalid for a non-positive extension, got %v", err)
+	}
+}
+
+func TestExtendIfClosing(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.extensionWindow = 30 * time.Second
+	repo.extensionDuration = time.Minute
+
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.clock = ClockFunc(func() time.Time { return clock })
+	deadline := clock.Add(20 * time.Second)
+	repo.trackAuction("late-bid", deadline, "books")
+	repo.trackAuction("early-bid", clock.Add(time.Hour), "books")
+
+	persisted := map[string]bson.M{}
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		persisted[filter["_id"].(string)] = update
+		return 1, nil
+	}
+	var changed []string
+	repo.OnAuctionChanged(func(auctionId string) { changed = append(changed, auctionId) })
+
+	endTime, extended := repo.ExtendIfClosing(context.Background(), "late-bid")
+	if !extended || !endTime.Equal(deadline.Add(time.Minute)) {
+		t.Fatalf("Expected late bid to extend the auction to %v, got %v (%v)", deadline.Add(time.Minute), endTime, extended)
+	}
+
+	if tracked := repo.activeAuctions["late-bid"]; !tracked.Equal(deadline.Add(time.Minute)) {
+		t.Errorf("Expected tracked end time to move forward, got %v", tracked)
+	}
+
+	expected := bson.M{"$set": bson.M{"end_time": deadline.Add(time.Minute).Unix()}}
+	if !reflect.DeepEqual(persisted["late-bid"], expected) {
+		t.Errorf("Expected the new end time to be persisted, got %v", persisted["late-bid"])
+	}
+
+	if _, extended := repo.ExtendIfClosing(context.Background(), "early-bid"); extended {
+		t.Errorf("Expected a bid outside the window not to extend the auction")
+	}
+
+	if _, extended := repo.ExtendIfClosing(context.Background(), "untracked"); extended {
+		t.Errorf("Expected an untracked auction not to be extended")
+	}
+
+	// Depois do prazo o lance não reabre o leilão
+	clock = clock.Add(2 * time.Hour)
+	if _, extended := repo.ExtendIfClosing(context.Background(), "early-bid"); extended {
+		t.Errorf("Expected an expired auction not to be extended")
+	}
+
+	// Só a prorrogação gravada invalida caches como o de término dos lances
+	if len(persisted) != 1 || len(changed) != 1 || changed[0] != "late-bid" {
+		t.Errorf("Expected only the late bid extension to be persisted and notified, got %v and %v", persisted, changed)
+	}
+}
+
+func TestExtendIfClosingDisabledByDefault(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.trackAuction("auction", time.Now().Add(time.Second), "books")
+
+	if _, extended := repo.ExtendIfClosing(context.Background(), "auction"); extended {
+		t.Errorf("Expected no extension without a configured window")
+	}
+}
+
+func TestGetExtensionSettings(t *testing.T) {
+	os.Setenv("AUCTION_EXTENSION_WINDOW", "30s")
+	os.Setenv("AUCTION_EXTENSION_DURATION", "-1m")
+	defer os.Unsetenv("AUCTION_EXTENSION_WINDOW")
+	defer os.Unsetenv("AUCTION_EXTENSION_DURATION")
+
+	if window := getExtensionWindow(); window != 30*time.Second {
+		t.Errorf("Expected 30s window, got %v", window)
+	}
+
+	if duration := getExtensionDuration(); duration != 0 {
+		t.Errorf("Expected negative duration to disable the extension, got %v", duration)
+	}
+}
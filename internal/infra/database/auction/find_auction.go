@@ -2,13 +2,19 @@ package auction
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"regexp"
+	"strings"
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"time"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
 )
 
 func (ar *AuctionRepository) FindAuctionById(
@@ -17,65 +23,227 @@ func (ar *AuctionRepository) FindAuctionById(
 
 	var auctionEntityMongo AuctionEntityMongo
 	if err := ar.Collection.FindOne(ctx, filter).Decode(&auctionEntityMongo); err != nil {
-		logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			ar.logger.Error("Auction not found", err, zap.String("auction_id", id))
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Auction not found with this id = %s", id))
+		}
+
+		ar.logger.Error("Error trying to find auction by id", err, zap.String("auction_id", id))
 		return nil, internal_error.NewInternalServerError("Error trying to find auction by id")
 	}
 
-	return &auction_entity.Auction{
-		Id:          auctionEntityMongo.Id,
-		ProductName: auctionEntityMongo.ProductName,
-		Category:    auctionEntityMongo.Category,
-		Description: auctionEntityMongo.Description,
-		Condition:   auctionEntityMongo.Condition,
-		Status:      auctionEntityMongo.Status,
-		Timestamp:   time.Unix(auctionEntityMongo.Timestamp, 0),
-	}, nil
+	if err := validateEnums(
+		auctionEntityMongo.Id, auctionEntityMongo.Status, auctionEntityMongo.Condition); err != nil {
+		return nil, err
+	}
+
+	return auctionEntityMongo.toEntity(ar.AuctionDuration()), nil
 }
 
 func (repo *AuctionRepository) FindAuctions(
 	ctx context.Context,
-	status auction_entity.AuctionStatus,
-	category string,
-	productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
-	filter := bson.M{}
+	status *auction_entity.AuctionStatus,
+	categories []string,
+	productName string,
+	search string,
+	from, to time.Time,
+	sort auction_entity.AuctionSortOrder) ([]auction_entity.Auction, *internal_error.InternalError) {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, internal_error.NewBadRequestError("from must not be after to").WithCode("created_range.invalid")
+	}
 
-	if status != 0 {
-		filter["status"] = status
+	filter := buildFindAuctionsFilter(status, categories, productName, search, repo.categoryCase)
+	applyTimestampRange(filter, from, to)
+
+	opts := options.Find().SetSort(findAuctionsSort(sort))
+	if repo.useIndexHints {
+		if hint := findAuctionsIndexHint(filter); hint != "" {
+			opts.SetHint(hint)
+		}
 	}
 
-	if category != "" {
-		filter["category"] = category
+	auctions, err := repo.findAuctionsByFilter(ctx, filter, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	if productName != "" {
-		filter["productName"] = primitive.Regex{Pattern: productName, Options: "i"}
+	// A validação fica aqui, e não em findAuctionsByFilter, para que um
+	// documento corrompido não impeça a recarga do monitor nem os relatórios
+	for _, auction := range auctions {
+		if err := validateEnums(auction.Id, auction.Status, auction.Condition); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(auctions) == 0 {
+		return nil, internal_error.NewNotFoundError("No auctions found matching the given filters")
 	}
 
-	cursor, err := repo.Collection.Find(ctx, filter)
+	return auctions, nil
+}
+
+// FindAuctionsByUser lista os leilões publicados pelo vendedor, dos mais
+// recentes aos mais antigos; status nil significa "qualquer status"
+func (repo *AuctionRepository) FindAuctionsByUser(
+	ctx context.Context,
+	sellerId string,
+	status *auction_entity.AuctionStatus) ([]auction_entity.Auction, *internal_error.InternalError) {
+	if sellerId == "" {
+		return nil, internal_error.NewBadRequestError("seller id is required").
+			WithCode("seller_id.empty")
+	}
+
+	filter := bson.M{"seller_id": sellerId}
+	if status != nil {
+		filter["status"] = *status
+	}
+
+	opts := options.Find().SetSort(findAuctionsSort(auction_entity.SortNewestFirst))
+	return repo.findAuctionsByFilter(ctx, filter, opts)
+}
+
+// Executa a consulta e converte os documentos em entidades
+func (repo *AuctionRepository) findAuctionsByFilter(
+	ctx context.Context,
+	filter bson.M,
+	opts ...*options.FindOptions) ([]auction_entity.Auction, *internal_error.InternalError) {
+	cursor, err := repo.Collection.Find(ctx, filter, opts...)
 	if err != nil {
-		logger.Error("Error finding auctions", err)
+		repo.logger.Error("Error finding auctions", err)
 		return nil, internal_error.NewInternalServerError("Error finding auctions")
 	}
 	defer cursor.Close(ctx)
 
 	var auctionsMongo []AuctionEntityMongo
 	if err := cursor.All(ctx, &auctionsMongo); err != nil {
-		logger.Error("Error decoding auctions", err)
+		repo.logger.Error("Error decoding auctions", err)
 		return nil, internal_error.NewInternalServerError("Error decoding auctions")
 	}
 
 	var auctionsEntity []auction_entity.Auction
 	for _, auction := range auctionsMongo {
-		auctionsEntity = append(auctionsEntity, auction_entity.Auction{
-			Id:          auction.Id,
-			ProductName: auction.ProductName,
-			Category:    auction.Category,
-			Status:      auction.Status,
-			Description: auction.Description,
-			Condition:   auction.Condition,
-			Timestamp:   time.Unix(auction.Timestamp, 0),
-		})
+		auctionsEntity = append(auctionsEntity, *auction.toEntity(repo.AuctionDuration()))
 	}
 
 	return auctionsEntity, nil
 }
+
+// Monta o filtro de busca; status nil significa "qualquer status", as
+// categorias são normalizadas em letterCase e o nome do produto é buscado
+// como trecho literal, sem diferenciar maiúsculas
+func buildFindAuctionsFilter(
+	status *auction_entity.AuctionStatus,
+	categories []string,
+	productName string,
+	search string,
+	letterCase auction_entity.CategoryCase) bson.M {
+	filter := bson.M{}
+
+	if status != nil {
+		filter["status"] = *status
+	}
+
+	// Basta uma das categorias pedidas estar na principal ou nas adicionais
+	var alternatives []bson.A
+	if normalized := normalizeCategories(categories, letterCase); len(normalized) > 0 {
+		alternatives = append(alternatives, bson.A{
+			bson.M{"category": bson.M{"$in": normalized}},
+			bson.M{"categories": bson.M{"$in": normalized}},
+		})
+	}
+
+	if productName != "" {
+		filter["product_name"] = primitive.Regex{Pattern: regexp.QuoteMeta(productName), Options: "i"}
+	}
+
+	// A busca por palavra-chave procura o termo no nome ou na descrição
+	if search = strings.TrimSpace(search); search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(search), Options: "i"}
+		alternatives = append(alternatives, bson.A{
+			bson.M{"product_name": pattern},
+			bson.M{"description": pattern},
+		})
+	}
+
+	// Cada grupo de alternativas vira um $or; com mais de um, todos precisam
+	// ser atendidos
+	switch len(alternatives) {
+	case 0:
+	case 1:
+		filter["$or"] = alternatives[0]
+	default:
+		and := bson.A{}
+		for _, alternative := range alternatives {
+			and = append(and, bson.M{"$or": alternative})
+		}
+		filter["$and"] = and
+	}
+
+	return filter
+}
+
+// Ordena pelo horário de criação; qualquer valor diferente de
+// SortOldestFirst lista os mais recentes primeiro
+func findAuctionsSort(sort auction_entity.AuctionSortOrder) bson.D {
+	direction := -1
+	if sort == auction_entity.SortOldestFirst {
+		direction = 1
+	}
+
+	return bson.D{{Key: "timestamp", Value: direction}}
+}
+
+// Normaliza as categorias pedidas na caixa gravada, descartando vazias e
+// repetidas
+func normalizeCategories(categories []string, letterCase auction_entity.CategoryCase) []string {
+	seen := map[string]bool{}
+	var normalized []string
+	for _, category := range categories {
+		category = auction_entity.NormalizeCategory(category, letterCase)
+		if category == "" || seen[category] {
+			continue
+		}
+		seen[category] = true
+		normalized = append(normalized, category)
+	}
+
+	return normalized
+}
+
+// Restringe o filtro aos leilões criados entre from e to (inclusive); um
+// limite zerado deixa o intervalo aberto daquele lado
+func applyTimestampRange(filter bson.M, from, to time.Time) {
+	timestampRange := bson.M{}
+	if !from.IsZero() {
+		timestampRange["$gte"] = from.Unix()
+	}
+	if !to.IsZero() {
+		timestampRange["$lte"] = to.Unix()
+	}
+
+	if len(timestampRange) > 0 {
+		filter["timestamp"] = timestampRange
+	}
+}
+
+// Escolhe o índice conhecido para o filtro: "status_1" ({status: 1})
+// quando há filtro de status. As categorias são procuradas com $or em
+// category e categories, e o MongoDB escolhe um índice para cada
+// alternativa; uma dica forçaria um único índice para todas, por isso
+// filtros com $or ficam sem dica
+//
+// Os índices precisam existir na coleção (ver EnsureIndexes), caso
+// contrário o MongoDB rejeita a consulta; por isso as dicas ficam
+// desligadas por padrão
+func findAuctionsIndexHint(filter bson.M) string {
+	if _, hasOr := filter["$or"]; hasOr {
+		return ""
+	}
+
+	if _, hasStatus := filter["status"]; hasStatus {
+		return "status_1"
+	}
+
+	return ""
+}
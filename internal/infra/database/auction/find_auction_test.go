@@ -0,0 +1,563 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestBuildFindAuctionsFilterAnyStatus(t *testing.T) {
+	filter := buildFindAuctionsFilter(nil, nil, "", "", auction_entity.CategoryLowerCase)
+
+	if _, exists := filter["status"]; exists {
+		t.Errorf("Expected no status filter when status is nil, got %v", filter["status"])
+	}
+}
+
+func TestBuildFindAuctionsFilterActiveOnly(t *testing.T) {
+	status := auction_entity.Active
+	filter := buildFindAuctionsFilter(&status, nil, "", "", auction_entity.CategoryLowerCase)
+
+	value, exists := filter["status"]
+	if !exists {
+		t.Fatalf("Expected status filter for Active auctions")
+	}
+
+	if value != auction_entity.Active {
+		t.Errorf("Expected status filter %v, got %v", auction_entity.Active, value)
+	}
+}
+
+func TestBuildFindAuctionsFilterCompletedOnly(t *testing.T) {
+	status := auction_entity.Completed
+	filter := buildFindAuctionsFilter(&status, []string{"Electronics"}, "", "", auction_entity.CategoryLowerCase)
+
+	if filter["status"] != auction_entity.Completed {
+		t.Errorf("Expected status filter %v, got %v", auction_entity.Completed, filter["status"])
+	}
+
+	expected := bson.A{
+		bson.M{"category": bson.M{"$in": []string{"electronics"}}},
+		bson.M{"categories": bson.M{"$in": []string{"electronics"}}},
+	}
+	if !reflect.DeepEqual(filter["$or"], expected) {
+		t.Errorf("Expected normalized category filter %v, got %v", expected, filter["$or"])
+	}
+}
+
+func TestBuildFindAuctionsFilterCombinations(t *testing.T) {
+	status := auction_entity.Active
+
+	testCases := []struct {
+		name        string
+		status      *auction_entity.AuctionStatus
+		categories  []string
+		productName string
+		expected    bson.M
+	}{
+		{"no filters", nil, nil, "", bson.M{}},
+		{"status only", &status, nil, "", bson.M{"status": status}},
+		{"category only", nil, []string{" Books "}, "", bson.M{"$or": bson.A{
+			bson.M{"category": bson.M{"$in": []string{"books"}}},
+			bson.M{"categories": bson.M{"$in": []string{"books"}}}}}},
+		{"product name only", nil, nil, "phone", bson.M{
+			"product_name": primitive.Regex{Pattern: "phone", Options: "i"}}},
+		{"all filters", &status, []string{"Books"}, "Go (2nd ed.)", bson.M{
+			"status": status,
+			"$or": bson.A{
+				bson.M{"category": bson.M{"$in": []string{"books"}}},
+				bson.M{"categories": bson.M{"$in": []string{"books"}}}},
+			"product_name": primitive.Regex{Pattern: `Go \(2nd ed\.\)`, Options: "i"}}},
+	}
+
+	for _, tc := range testCases {
+		filter := buildFindAuctionsFilter(tc.status, tc.categories, tc.productName, "", auction_entity.CategoryLowerCase)
+		if !reflect.DeepEqual(filter, tc.expected) {
+			t.Errorf("%s: expected filter %v, got %v", tc.name, tc.expected, filter)
+		}
+	}
+}
+
+func TestBuildFindAuctionsFilterSearch(t *testing.T) {
+	filter := buildFindAuctionsFilter(nil, nil, "", "  c++ (used)  ", auction_entity.CategoryLowerCase)
+
+	pattern := primitive.Regex{Pattern: `c\+\+ \(used\)`, Options: "i"}
+	expected := bson.M{"$or": bson.A{
+		bson.M{"product_name": pattern},
+		bson.M{"description": pattern},
+	}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("Expected search across name and description %v, got %v", expected, filter)
+	}
+
+	if filter := buildFindAuctionsFilter(nil, nil, "", "   ", auction_entity.CategoryLowerCase); len(filter) != 0 {
+		t.Errorf("Expected a blank search to be ignored, got %v", filter)
+	}
+}
+
+func TestBuildFindAuctionsFilterMultipleCategories(t *testing.T) {
+	filter := buildFindAuctionsFilter(nil, []string{"Books", " books ", "", "Music"}, "", "guitar", auction_entity.CategoryLowerCase)
+
+	categories := []string{"books", "music"}
+	pattern := primitive.Regex{Pattern: "guitar", Options: "i"}
+	expected := bson.M{"$and": bson.A{
+		bson.M{"$or": bson.A{
+			bson.M{"category": bson.M{"$in": categories}},
+			bson.M{"categories": bson.M{"$in": categories}},
+		}},
+		bson.M{"$or": bson.A{
+			bson.M{"product_name": pattern},
+			bson.M{"description": pattern},
+		}},
+	}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("Expected categories and search to be combined %v, got %v", expected, filter)
+	}
+}
+
+func TestFindAuctionsByMultipleCategories(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	auctions := []interface{}{
+		AuctionEntityMongo{Id: "novel", Condition: auction_entity.New, ProductName: "Novel", Category: "books",
+			Description: "Paperback novel", Status: auction_entity.Active},
+		AuctionEntityMongo{Id: "songbook", Condition: auction_entity.Used, ProductName: "Songbook", Category: "music",
+			Categories: []string{"books"}, Description: "Guitar songbook", Status: auction_entity.Active},
+		AuctionEntityMongo{Id: "sofa", Condition: auction_entity.Used, ProductName: "Sofa", Category: "home",
+			Description: "Three-seat sofa", Status: auction_entity.Active},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	// "books" é a categoria principal de um leilão e adicional do outro
+	found, err := repo.FindAuctions(ctx, nil, []string{"Books"}, "", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if err != nil || len(found) != 2 {
+		t.Errorf("Expected auctions matching the primary and the additional category, got %+v (%v)", found, err)
+	}
+
+	found, err = repo.FindAuctions(ctx, nil, []string{"books", "home"}, "", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if err != nil || len(found) != 3 {
+		t.Errorf("Expected auctions matching any of the categories, got %+v (%v)", found, err)
+	}
+}
+
+func TestFindAuctionsSearchesDescription(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	auctions := []interface{}{
+		AuctionEntityMongo{Id: "guitar", Condition: auction_entity.Used, ProductName: "Guitar", Category: "music",
+			Description: "Vintage instrument with a Rosewood fretboard", Status: auction_entity.Active},
+		AuctionEntityMongo{Id: "rosewood-table", Condition: auction_entity.New, ProductName: "Rosewood Table", Category: "home",
+			Description: "Dining table for six", Status: auction_entity.Active},
+		AuctionEntityMongo{Id: "drum", Condition: auction_entity.New, ProductName: "Drum", Category: "music",
+			Description: "Snare drum (14 inch)", Status: auction_entity.Active},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	found, err := repo.FindAuctions(ctx, nil, []string{"music"}, "", "ROSEWOOD", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if err != nil {
+		t.Fatalf("Expected auctions, got %v", err)
+	}
+	if len(found) != 1 || found[0].Id != "guitar" {
+		t.Errorf("Expected the auction with the term only in its description, got %+v", found)
+	}
+
+	found, err = repo.FindAuctions(ctx, nil, nil, "", "rosewood", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if err != nil || len(found) != 2 {
+		t.Errorf("Expected matches in both name and description, got %+v (%v)", found, err)
+	}
+
+	// Metacaracteres são procurados literalmente
+	found, err = repo.FindAuctions(ctx, nil, nil, "", "(14 inch)", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if err != nil || len(found) != 1 || found[0].Id != "drum" {
+		t.Errorf("Expected a literal match for the parenthesized term, got %+v (%v)", found, err)
+	}
+}
+
+func TestApplyTimestampRange(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		from, to time.Time
+		expected bson.M
+	}{
+		{"no bounds", time.Time{}, time.Time{}, bson.M{}},
+		{"from only", from, time.Time{}, bson.M{"timestamp": bson.M{"$gte": from.Unix()}}},
+		{"to only", time.Time{}, to, bson.M{"timestamp": bson.M{"$lte": to.Unix()}}},
+		{"bounded", from, to, bson.M{"timestamp": bson.M{"$gte": from.Unix(), "$lte": to.Unix()}}},
+	}
+
+	for _, tc := range testCases {
+		filter := bson.M{}
+		applyTimestampRange(filter, tc.from, tc.to)
+		if !reflect.DeepEqual(filter, tc.expected) {
+			t.Errorf("%s: expected filter %v, got %v", tc.name, tc.expected, filter)
+		}
+	}
+}
+
+func TestFindAuctionsRejectsInvertedRange(t *testing.T) {
+	repo := setupInMemoryRepository()
+	from := time.Now()
+
+	_, err := repo.FindAuctions(context.Background(), nil, nil, "", "", from, from.Add(-time.Hour), auction_entity.SortNewestFirst)
+	if err == nil || err.Code != "created_range.invalid" {
+		t.Errorf("Expected created_range.invalid, got %v", err)
+	}
+}
+
+func TestFindAuctionsByCreationRange(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	auctions := []interface{}{
+		AuctionEntityMongo{Id: "february", Condition: auction_entity.New, Category: "books", Timestamp: base.AddDate(0, -1, 0).Unix()},
+		AuctionEntityMongo{Id: "march", Condition: auction_entity.New, Category: "books", Timestamp: base.Unix()},
+		AuctionEntityMongo{Id: "april", Condition: auction_entity.New, Category: "books", Timestamp: base.AddDate(0, 1, 0).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	ids := func(from, to time.Time) []string {
+		found, err := repo.FindAuctions(ctx, nil, nil, "", "", from, to, auction_entity.SortOldestFirst)
+		if err != nil {
+			t.Fatalf("Expected auctions between %v and %v, got %v", from, to, err)
+		}
+		var result []string
+		for _, auction := range found {
+			result = append(result, auction.Id)
+		}
+		return result
+	}
+
+	if got := ids(base, time.Time{}); !reflect.DeepEqual(got, []string{"march", "april"}) {
+		t.Errorf("Expected auctions from march on, got %v", got)
+	}
+	if got := ids(time.Time{}, base); !reflect.DeepEqual(got, []string{"february", "march"}) {
+		t.Errorf("Expected auctions up to march, got %v", got)
+	}
+	if got := ids(base.AddDate(0, 0, -1), base.AddDate(0, 0, 1)); !reflect.DeepEqual(got, []string{"march"}) {
+		t.Errorf("Expected only the march auction, got %v", got)
+	}
+}
+
+func TestFindAuctions(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	auctions := []interface{}{
+		AuctionEntityMongo{Id: "phone", Condition: auction_entity.New, ProductName: "Smartphone X", Category: "electronics",
+			Status: auction_entity.Active},
+		AuctionEntityMongo{Id: "book", Condition: auction_entity.New, ProductName: "Go Book", Category: "books",
+			Status: auction_entity.Completed},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	active := auction_entity.Active
+	found, err := repo.FindAuctions(ctx, &active, []string{"Electronics"}, "PHONE", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if err != nil {
+		t.Fatalf("Expected auctions, got %v", err)
+	}
+	if len(found) != 1 || found[0].Id != "phone" {
+		t.Errorf("Expected only the phone auction, got %+v", found)
+	}
+
+	if found, err := repo.FindAuctions(ctx, nil, nil, "book", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst); err != nil || len(found) != 1 {
+		t.Errorf("Expected the book auction for any status, got %+v (%v)", found, err)
+	}
+
+	if _, err := repo.FindAuctions(ctx, &active, []string{"books"}, "", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found when nothing matches, got %v", err)
+	}
+}
+
+func TestFindAuctionsSort(t *testing.T) {
+	if sort := findAuctionsSort(auction_entity.SortNewestFirst); !reflect.DeepEqual(sort, bson.D{{Key: "timestamp", Value: -1}}) {
+		t.Errorf("Expected newest first to sort by timestamp descending, got %v", sort)
+	}
+
+	if sort := findAuctionsSort(auction_entity.SortOldestFirst); !reflect.DeepEqual(sort, bson.D{{Key: "timestamp", Value: 1}}) {
+		t.Errorf("Expected oldest first to sort by timestamp ascending, got %v", sort)
+	}
+}
+
+func TestFindAuctionsSortsByTimestamp(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	now := time.Now()
+	auctions := []interface{}{
+		AuctionEntityMongo{Id: "middle", Condition: auction_entity.New, Category: "books", Timestamp: now.Add(-time.Minute).Unix()},
+		AuctionEntityMongo{Id: "newest", Condition: auction_entity.New, Category: "books", Timestamp: now.Unix()},
+		AuctionEntityMongo{Id: "oldest", Condition: auction_entity.New, Category: "books", Timestamp: now.Add(-2 * time.Minute).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	newestFirst, err := repo.FindAuctions(ctx, nil, []string{"books"}, "", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if err != nil {
+		t.Fatalf("Expected auctions, got %v", err)
+	}
+	if len(newestFirst) != 3 || newestFirst[0].Id != "newest" || newestFirst[2].Id != "oldest" {
+		t.Errorf("Expected the most recent auction first, got %+v", newestFirst)
+	}
+
+	oldestFirst, err := repo.FindAuctions(ctx, nil, []string{"books"}, "", "", time.Time{}, time.Time{}, auction_entity.SortOldestFirst)
+	if err != nil {
+		t.Fatalf("Expected auctions, got %v", err)
+	}
+	if len(oldestFirst) != 3 || oldestFirst[0].Id != "oldest" {
+		t.Errorf("Expected the oldest auction first, got %+v", oldestFirst)
+	}
+}
+
+func TestFindAuctionsIndexHint(t *testing.T) {
+	status := auction_entity.Active
+
+	testCases := []struct {
+		name     string
+		filter   bson.M
+		expected string
+	}{
+		{"status and category", buildFindAuctionsFilter(&status, []string{"books"}, "", "", auction_entity.CategoryLowerCase), ""},
+		{"status only", buildFindAuctionsFilter(&status, nil, "", "", auction_entity.CategoryLowerCase), "status_1"},
+		{"category only", buildFindAuctionsFilter(nil, []string{"books"}, "", "", auction_entity.CategoryLowerCase), ""},
+		{"no indexed filter", buildFindAuctionsFilter(nil, nil, "phone", "", auction_entity.CategoryLowerCase), ""},
+	}
+
+	for _, tc := range testCases {
+		if hint := findAuctionsIndexHint(tc.filter); hint != tc.expected {
+			t.Errorf("%s: expected hint %q, got %q", tc.name, tc.expected, hint)
+		}
+	}
+}
+
+func TestFindAuctionsAppliesIndexHint(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+	repo.useIndexHints = true
+
+	if _, err := repo.Collection.InsertOne(ctx, AuctionEntityMongo{Id: "auction", Condition: auction_entity.New, Category: "books"}); err != nil {
+		t.Fatalf("Failed to seed auction: %v", err)
+	}
+
+	status := auction_entity.Active
+
+	// Sem o índice, a dica faz o MongoDB rejeitar a consulta
+	if _, err := repo.FindAuctions(ctx, &status, nil, "", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst); err == nil {
+		t.Fatalf("Expected query hinted at a missing index to fail")
+	}
+
+	// Com categoria o filtro usa $or e segue sem dica
+	if _, err := repo.FindAuctions(ctx, &status, []string{"books"}, "", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst); err != nil {
+		t.Fatalf("Expected category query without hint to succeed, got %v", err)
+	}
+
+	_, err := repo.Collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+
+	auctions, findErr := repo.FindAuctions(ctx, &status, nil, "", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if findErr != nil {
+		t.Fatalf("Expected hinted query to succeed, got %v", findErr)
+	}
+
+	if len(auctions) != 1 {
+		t.Errorf("Expected 1 auction, got %d", len(auctions))
+	}
+}
+
+// Leilão com todos os campos persistidos preenchidos; o timestamp é
+// truncado em segundos, que é a precisão gravada no banco
+func newRoundTripAuction(t *testing.T) *auction_entity.Auction {
+	t.Helper()
+
+	auction, err := auction_entity.CreateAuction("seller", "Phone", "  Consumer Electronics ", "A valid description", auction_entity.Used)
+	if err != nil {
+		t.Fatalf("Failed to build auction: %v", err)
+	}
+	auction.Timestamp = auction.Timestamp.Truncate(time.Second)
+	auction.NotificationTemplateId = "auction_won"
+	auction.NotificationMetadata = map[string]string{"lang": "pt"}
+	auction.RelistedFrom = "original"
+	auction.RelistCount = 1
+	auction.BuyNowPrice = 1500
+	auction.ReservePrice = 800
+	auction.Duration = time.Hour
+	auction.EndTime = auctionEndTime(auction, getAuctionDuration())
+
+	return auction
+}
+
+func TestAuctionRoundTripsThroughMongoEntity(t *testing.T) {
+	repo := setupInMemoryRepository()
+	auction := newRoundTripAuction(t)
+
+	var stored *AuctionEntityMongo
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		stored = auction
+		return nil
+	}
+
+	if _, err := repo.CreateAuction(context.Background(), auction); err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	if got := stored.ToEntity(); !reflect.DeepEqual(got, auction) {
+		t.Errorf("Expected %+v, got %+v", auction, got)
+	}
+}
+
+func TestFindAuctionById(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	auction := newRoundTripAuction(t)
+	if _, err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	found, err := repo.FindAuctionById(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Expected auction to be found, got %v", err)
+	}
+
+	if !reflect.DeepEqual(found, auction) {
+		t.Errorf("Expected %+v, got %+v", auction, found)
+	}
+
+	if _, err := repo.FindAuctionById(ctx, "missing"); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
+
+func TestValidateEnumsRejectsOutOfRangeValues(t *testing.T) {
+	if err := validateEnums("valid", auction_entity.Paused, auction_entity.Used); err != nil {
+		t.Errorf("Expected valid values to pass, got %v", err)
+	}
+
+	if err := validateEnums("bad-condition", auction_entity.Active, 99); err == nil || err.Err != "internal_server_error" {
+		t.Errorf("Expected internal_server_error for an invalid condition, got %v", err)
+	}
+
+	if err := validateEnums("bad-status", 42, auction_entity.New); err == nil || err.Err != "internal_server_error" {
+		t.Errorf("Expected internal_server_error for an invalid status, got %v", err)
+	}
+}
+
+func TestFindRejectsDocumentWithInvalidCondition(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	raw := bson.M{"_id": "corrupted", "product_name": "Phone", "category": "electronics",
+		"condition": 99, "status": auction_entity.Active, "timestamp": time.Now().Unix()}
+	if _, err := repo.Collection.InsertOne(ctx, raw); err != nil {
+		t.Fatalf("Failed to seed auction: %v", err)
+	}
+
+	auction, err := repo.FindAuctionById(ctx, "corrupted")
+	if auction != nil || err == nil || err.Err != "internal_server_error" {
+		t.Errorf("Expected internal_server_error reading a corrupted auction, got %v, %v", auction, err)
+	}
+
+	auctions, err := repo.FindAuctions(ctx, nil, nil, "", "", time.Time{}, time.Time{}, auction_entity.SortNewestFirst)
+	if auctions != nil || err == nil || err.Err != "internal_server_error" {
+		t.Errorf("Expected internal_server_error listing a corrupted auction, got %v, %v", auctions, err)
+	}
+}
+
+func TestFindAuctionsByUser(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	now := time.Now()
+	auctions := []interface{}{
+		AuctionEntityMongo{Id: "alice-active", SellerId: "alice", Condition: auction_entity.New,
+			Status: auction_entity.Active, Timestamp: now.Unix()},
+		AuctionEntityMongo{Id: "alice-completed", SellerId: "alice", Condition: auction_entity.New,
+			Status: auction_entity.Completed, Timestamp: now.Add(-time.Hour).Unix()},
+		AuctionEntityMongo{Id: "bob-active", SellerId: "bob", Condition: auction_entity.New,
+			Status: auction_entity.Active, Timestamp: now.Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	found, err := repo.FindAuctionsByUser(ctx, "alice", nil)
+	if err != nil {
+		t.Fatalf("Failed to find auctions by user: %v", err)
+	}
+	if len(found) != 2 || found[0].Id != "alice-active" || found[1].Id != "alice-completed" {
+		t.Errorf("Expected only alice's auctions, newest first, got %v", found)
+	}
+
+	status := auction_entity.Active
+	found, err = repo.FindAuctionsByUser(ctx, "bob", &status)
+	if err != nil {
+		t.Fatalf("Failed to find auctions by user and status: %v", err)
+	}
+	if len(found) != 1 || found[0].Id != "bob-active" {
+		t.Errorf("Expected only bob's active auction, got %v", found)
+	}
+
+	completed := auction_entity.Completed
+	if found, err := repo.FindAuctionsByUser(ctx, "bob", &completed); err != nil || len(found) != 0 {
+		t.Errorf("Expected no completed auctions for bob, got %v (%v)", found, err)
+	}
+}
+
+func TestFindAuctionsByUserRequiresSeller(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	if _, err := repo.FindAuctionsByUser(context.Background(), "", nil); err == nil || err.Code != "seller_id.empty" {
+		t.Errorf("Expected seller_id.empty without a seller, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// FreezeAuction pausa a contagem regressiva de um leilão ativo. É a mesma
+// pausa de PauseAuction: o status Paused fica gravado no banco, então o
+// congelamento vale para todas as instâncias e sobrevive a reinicializações
+func (ar *AuctionRepository) FreezeAuction(ctx context.Context, id string) *internal_error.InternalError {
+	return ar.PauseAuction(ctx, id)
+}
+
+// UnfreezeAuction volta a monitorar um leilão congelado com o tempo restante
+// que ele tinha ao ser congelado, como ResumeAuction
+func (ar *AuctionRepository) UnfreezeAuction(ctx context.Context, id string) *internal_error.InternalError {
+	_, err := ar.ResumeAuction(ctx, id)
+	return err
+}
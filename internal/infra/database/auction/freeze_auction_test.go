@@ -0,0 +1,72 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+func TestFreezeAndUnfreezePreservesRemainingTime(t *testing.T) {
+	repo := setupInMemoryRepository()
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.clock = ClockFunc(func() time.Time { return clock })
+
+	auction := &auction_entity.Auction{Id: "auction", Category: "books", Status: auction_entity.Active,
+		EndTime: clock.Add(3 * time.Minute)}
+	stubFindAuctionById(repo, auction)
+	stubAuctionUpdates(repo, auction)
+	repo.trackAuction("auction", auction.EndTime, "books")
+
+	if err := repo.FreezeAuction(context.Background(), "auction"); err != nil {
+		t.Fatalf("Expected auction to be frozen, got %v", err)
+	}
+
+	// O congelamento fica gravado como a pausa
+	if auction.Status != auction_entity.Paused {
+		t.Fatalf("Expected frozen auction to be stored as paused, got %v", auction.Status)
+	}
+	if _, tracked := repo.activeAuctions["auction"]; tracked {
+		t.Fatalf("Expected frozen auction to leave active tracking")
+	}
+
+	// O tempo passa enquanto o leilão está pausado
+	clock = clock.Add(time.Hour)
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if err := repo.UnfreezeAuction(context.Background(), "auction"); err != nil {
+		t.Fatalf("Expected auction to be unfrozen, got %v", err)
+	}
+
+	endTime, tracked := repo.activeAuctions["auction"]
+	if !tracked || auction.Status != auction_entity.Active {
+		t.Fatalf("Expected unfrozen auction to be active and tracked again")
+	}
+
+	if expected := clock.Add(3 * time.Minute); !endTime.Equal(expected) {
+		t.Errorf("Expected end time %v, got %v", expected, endTime)
+	}
+
+	if got := repo.categoryGauge.Value("books", "active"); got != 1 {
+		t.Errorf("Expected active gauge back at 1, got %v", got)
+	}
+}
+
+func TestFreezeAuctionRejections(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo,
+		&auction_entity.Auction{Id: "completed", Status: auction_entity.Completed},
+		&auction_entity.Auction{Id: "untracked", Status: auction_entity.Active})
+
+	if err := repo.FreezeAuction(context.Background(), "completed"); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request freezing a completed auction, got %v", err)
+	}
+
+	if err := repo.FreezeAuction(context.Background(), "untracked"); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request freezing an untracked auction, got %v", err)
+	}
+
+	if err := repo.UnfreezeAuction(context.Background(), "untracked"); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request unfreezing an auction that is not frozen, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package auction
+
+import (
+	"os"
+	"time"
+)
+
+// GracePeriod retorna a tolerância após o fim do leilão em que lances
+// atrasados ainda são aceitos; o fechamento só acontece depois dela
+func (ar *AuctionRepository) GracePeriod() time.Duration {
+	return ar.gracePeriod
+}
+
+// Lê o período de tolerância de AUCTION_GRACE_PERIOD (padrão: 0)
+func getGracePeriod() time.Duration {
+	grace, err := time.ParseDuration(os.Getenv("AUCTION_GRACE_PERIOD"))
+	if err != nil || grace < 0 {
+		return 0
+	}
+
+	return grace
+}
@@ -0,0 +1,49 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGracePeriodDelaysCompletion(t *testing.T) {
+	repo := setupInMemoryRepository()
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	repo.clock = clock
+	repo.gracePeriod = 2 * time.Second
+	repo.trackAuction("auction", clock.Now(), "books")
+
+	// Dentro da tolerância o leilão continua aberto para lances atrasados
+	clock.Advance(time.Second)
+	repo.processExpiredAuctions(context.Background(), 0)
+	if !repo.isTracked("auction") {
+		t.Fatalf("Expected auction within the grace period to stay open")
+	}
+
+	clock.Advance(2 * time.Second)
+	repo.processExpiredAuctions(context.Background(), 0)
+	if repo.isTracked("auction") {
+		t.Errorf("Expected auction past the grace period to be completed")
+	}
+}
+
+func TestGetGracePeriod(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"750ms", 750 * time.Millisecond},
+		{"-1s", 0},
+		{"invalid", 0},
+	}
+
+	for _, tc := range testCases {
+		os.Setenv("AUCTION_GRACE_PERIOD", tc.value)
+		if got := getGracePeriod(); got != tc.expected {
+			t.Errorf("For %q expected %v, got %v", tc.value, tc.expected, got)
+		}
+	}
+	os.Unsetenv("AUCTION_GRACE_PERIOD")
+}
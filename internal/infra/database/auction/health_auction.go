@@ -0,0 +1,28 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindStaleActive retorna leilões ainda ativos cujo término ficou mais de
+// olderThan no passado, o que indica que o monitor falhou em fechá-los
+func (ar *AuctionRepository) FindStaleActive(
+	ctx context.Context, olderThan time.Duration) ([]auction_entity.Auction, *internal_error.InternalError) {
+	if olderThan < 0 {
+		return nil, internal_error.NewBadRequestError("olderThan must not be negative")
+	}
+
+	return ar.findAuctionsByFilter(ctx, staleActiveFilter(ar.now(), olderThan, ar.AuctionDuration()))
+}
+
+// Ativos com término anterior a now - olderThan
+func staleActiveFilter(now time.Time, olderThan, duration time.Duration) bson.M {
+	filter := endTimeFilter("$lt", now.Add(-olderThan), duration)
+	filter["status"] = auction_entity.Active
+	return filter
+}
@@ -0,0 +1,58 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStaleActiveFilter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	filter := staleActiveFilter(now, 10*time.Minute, time.Hour)
+
+	if filter["status"] != auction_entity.Active {
+		t.Errorf("Expected filter on Active status, got %v", filter["status"])
+	}
+
+	// Término gravado antes de now - 10min ou, sem end_time, criação antes
+	// de now - 70min
+	expected := endTimeFilter("$lt", now.Add(-10*time.Minute), time.Hour)["$or"]
+	if !reflect.DeepEqual(filter["$or"], expected) {
+		t.Errorf("Expected end time filter %v, got %v", expected, filter["$or"])
+	}
+}
+
+func TestFindStaleActive(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	os.Setenv("AUCTION_INTERVAL", "1m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	now := time.Now()
+	seeds := []interface{}{
+		// Terminou há 1 hora e continua ativo
+		AuctionEntityMongo{Id: "stuck", Status: auction_entity.Active, Timestamp: now.Add(-61 * time.Minute).Unix()},
+		// Terminou há poucos segundos, ainda dentro da tolerância
+		AuctionEntityMongo{Id: "recent", Status: auction_entity.Active, Timestamp: now.Add(-70 * time.Second).Unix()},
+		AuctionEntityMongo{Id: "closed", Status: auction_entity.Completed, Timestamp: now.Add(-2 * time.Hour).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seeds); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	auctions, err := repo.FindStaleActive(ctx, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to find stale auctions: %v", err)
+	}
+
+	if len(auctions) != 1 || auctions[0].Id != "stuck" {
+		t.Errorf("Expected only the stuck auction to be reported, got %+v", auctions)
+	}
+}
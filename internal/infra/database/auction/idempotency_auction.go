@@ -0,0 +1,30 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// Implementação real da busca do leilão criado com a chave de idempotência
+// do vendedor, usada quando uma criação repetida esbarra no índice único
+func (ar *AuctionRepository) findAuctionByIdempotencyKeyImpl(
+	ctx context.Context, sellerId, key string) (*auction_entity.Auction, *internal_error.InternalError) {
+	ctx, cancel := context.WithTimeout(ctx, ar.opTimeout)
+	defer cancel()
+
+	var auctionEntityMongo AuctionEntityMongo
+	filter := bson.M{"seller_id": sellerId, "idempotency_key": key}
+	if err := ar.Collection.FindOne(ctx, filter).Decode(&auctionEntityMongo); err != nil {
+		ar.logger.Error("Error trying to find auction with idempotency key", err,
+			zap.String("idempotency_key", key))
+		return nil, internal_error.NewInternalServerError("Error trying to find the existing auction")
+	}
+
+	ar.logger.Info("Auction create retried with an existing idempotency key",
+		zap.String("auction_id", auctionEntityMongo.Id))
+	return auctionEntityMongo.toEntity(ar.AuctionDuration()), nil
+}
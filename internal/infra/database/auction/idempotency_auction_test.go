@@ -0,0 +1,109 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newAuctionWithKey(t *testing.T, key string) *auction_entity.Auction {
+	t.Helper()
+
+	auction, err := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	if err != nil {
+		t.Fatalf("Failed to create auction entity: %v", err)
+	}
+	auction.IdempotencyKey = key
+	return auction
+}
+
+func TestCreateAuctionRetryReturnsExistingAuction(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	stored := make(map[string]*AuctionEntityMongo)
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		if _, exists := stored[auction.IdempotencyKey]; exists {
+			return mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+		}
+		stored[auction.IdempotencyKey] = auction
+		return nil
+	}
+	repo.findAuctionByIdempotencyKey = func(
+		ctx context.Context, sellerId, key string) (*auction_entity.Auction, *internal_error.InternalError) {
+		return stored[key].ToEntity(), nil
+	}
+
+	first, err := repo.CreateAuction(context.Background(), newAuctionWithKey(t, "key"))
+	if err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	retried, err := repo.CreateAuction(context.Background(), newAuctionWithKey(t, "key"))
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+
+	if retried.Id != first.Id {
+		t.Errorf("Expected retry to return auction %s, got %s", first.Id, retried.Id)
+	}
+
+	if count := repo.ActiveAuctionCount(); count != 1 {
+		t.Errorf("Expected a single tracked auction, got %d", count)
+	}
+}
+
+func TestCreateAuctionDuplicateWithoutKeyFails(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		return mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+	}
+
+	if _, err := repo.CreateAuction(context.Background(), newAuctionWithKey(t, "")); err == nil {
+		t.Errorf("Expected duplicate insert without idempotency key to fail")
+	}
+}
+
+func TestCreateAuctionWithSameKeyStoresOneDocument(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("Failed to ensure indexes: %v", err)
+	}
+
+	first, err := repo.CreateAuction(ctx, newAuctionWithKey(t, "retry-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	retried, err := repo.CreateAuction(ctx, newAuctionWithKey(t, "retry-key"))
+	if err != nil {
+		t.Fatalf("Failed to retry auction creation: %v", err)
+	}
+
+	if retried.Id != first.Id {
+		t.Errorf("Expected retry to return auction %s, got %s", first.Id, retried.Id)
+	}
+
+	count, countErr := repo.Collection.CountDocuments(ctx, bson.M{"idempotency_key": "retry-key"})
+	if countErr != nil {
+		t.Fatalf("Failed to count auctions: %v", countErr)
+	}
+	if count != 1 {
+		t.Errorf("Expected a single auction document, got %d", count)
+	}
+
+	// Leilões sem chave não disputam o índice único
+	for i := 0; i < 2; i++ {
+		if _, err := repo.CreateAuction(ctx, newAuctionWithKey(t, "")); err != nil {
+			t.Fatalf("Expected auction without key to be created, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Índices usados por FindAuctions e FindAuctionsByUser, incluindo os indicados nas dicas de
+// findAuctionsIndexHint, e o índice único das chaves de idempotência (só
+// para documentos que têm a chave). Os nomes são fixos para que as dicas os encontrem
+var auctionIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{Key: "status", Value: 1}}, Options: options.Index().SetName("status_1")},
+	{Keys: bson.D{{Key: "category", Value: 1}}, Options: options.Index().SetName("category_1")},
+	{Keys: bson.D{{Key: "status", Value: 1}, {Key: "category", Value: 1}},
+		Options: options.Index().SetName("status_1_category_1")},
+	{Keys: bson.D{{Key: "categories", Value: 1}}, Options: options.Index().SetName("categories_1")},
+	{Keys: bson.D{{Key: "product_name", Value: 1}}, Options: options.Index().SetName("product_name_1")},
+	{Keys: bson.D{{Key: "timestamp", Value: 1}}, Options: options.Index().SetName("timestamp_1")},
+	{Keys: bson.D{{Key: "seller_id", Value: 1}}, Options: options.Index().SetName("seller_id_1")},
+	{Keys: bson.D{{Key: "seller_id", Value: 1}, {Key: "idempotency_key", Value: 1}},
+		Options: options.Index().SetName("seller_id_1_idempotency_key_1").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$exists": true}})},
+}
+
+// EnsureIndexes cria os índices da coleção de leilões. Criar um índice que
+// já existe com a mesma definição não tem efeito, então pode ser chamada a
+// cada inicialização; NewAuctionRepository não a chama, cabe a quem monta
+// a aplicação fazê-lo
+func (ar *AuctionRepository) EnsureIndexes(ctx context.Context) *internal_error.InternalError {
+	if _, err := ar.Collection.Indexes().CreateMany(ctx, auctionIndexes); err != nil {
+		ar.logger.Error("Error trying to create auction indexes", err)
+		return internal_error.NewInternalServerError("Error trying to create auction indexes")
+	}
+
+	return nil
+}
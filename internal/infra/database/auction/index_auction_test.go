@@ -0,0 +1,42 @@
+package auction
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestEnsureIndexes(t *testing.T) {
+	database := setupMongoDatabase(t)
+	repo := &AuctionRepository{Collection: database.Collection("auctions")}
+	ctx := context.Background()
+
+	// Chamadas repetidas não devem falhar
+	for i := 0; i < 2; i++ {
+		if err := repo.EnsureIndexes(ctx); err != nil {
+			t.Fatalf("Failed to ensure indexes on call %d: %v", i+1, err)
+		}
+	}
+
+	cursor, err := repo.Collection.Indexes().List(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list indexes: %v", err)
+	}
+
+	var indexes []bson.M
+	if err := cursor.All(ctx, &indexes); err != nil {
+		t.Fatalf("Failed to decode indexes: %v", err)
+	}
+
+	names := make(map[string]bool)
+	for _, index := range indexes {
+		names[index["name"].(string)] = true
+	}
+
+	for _, expected := range []string{"status_1", "category_1", "status_1_category_1", "categories_1", "product_name_1", "timestamp_1", "seller_id_1", "seller_id_1_idempotency_key_1"} {
+		if !names[expected] {
+			t.Errorf("Expected index %s to exist, got %v", expected, names)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// CloseIfInstant encerra o leilão na hora quando, com AUCTION_INSTANT_CLOSE
+// ativado, o lance (amount, na moeda base) atinge o preço de compra
+// imediata e a reserva. O fechamento usa a mesma escrita atômica de BuyNow,
+// com o lance como vencedor. Retorna se o leilão foi encerrado; nesse caso
+// o lance não prorroga o leilão (ExtendIfClosing)
+func (ar *AuctionRepository) CloseIfInstant(
+	ctx context.Context, auctionId, userId, bidId string, amount float64) bool {
+	if !ar.instantClose {
+		return false
+	}
+
+	auctionEntity, err := ar.findAuctionById(ctx, auctionId)
+	if err != nil || !reachesInstantClose(auctionEntity, amount) {
+		return false
+	}
+
+	if err := ar.closeWithWinner(ctx, auctionEntity, bson.M{
+		"winner_user_id": userId,
+		"winner_bid_id":  bidId,
+		"final_price":    amount,
+	}); err != nil {
+		ar.logger.Warn("Auction not closed instantly",
+			zap.String("auction_id", auctionId),
+			zap.String("reason", err.Message))
+		return false
+	}
+
+	ar.logger.Info("Auction closed instantly by a bid",
+		zap.String("auction_id", auctionId),
+		zap.String("user_id", userId),
+		zap.Float64("price", amount))
+	ar.publishAuctionClosed(auctionId)
+
+	return true
+}
+
+// O lance encerra o leilão se atingir o preço de compra imediata e a
+// reserva; sem compra imediata não há limite
+func reachesInstantClose(auctionEntity *auction_entity.Auction, amount float64) bool {
+	return auctionEntity.BuyNowPrice > 0 &&
+		amount >= auctionEntity.BuyNowPrice &&
+		amount >= auctionEntity.ReservePrice
+}
+
+// Lê AUCTION_INSTANT_CLOSE (padrão: desativado)
+func getInstantClose() bool {
+	return os.Getenv("AUCTION_INSTANT_CLOSE") == "true"
+}
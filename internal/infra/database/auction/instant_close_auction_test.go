@@ -0,0 +1,95 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestQualifyingBidClosesAuctionInstantly(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.instantClose = true
+	repo.extensionWindow = time.Hour
+	repo.extensionDuration = time.Minute
+	stubFindAuctionById(repo, &auction_entity.Auction{
+		Id: "auction", Category: "books", Status: auction_entity.Active,
+		BuyNowPrice: 500, ReservePrice: 500, EndTime: time.Now().Add(time.Minute)})
+
+	var updates []bson.M
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		updates = append(updates, update)
+		return 1, nil
+	}
+	var events []string
+	repo.OnAuctionClosed = func(event AuctionClosedEvent) {
+		events = append(events, event.AuctionId)
+	}
+
+	repo.trackAuction("auction", time.Now().Add(time.Minute), "books")
+
+	if !repo.CloseIfInstant(context.Background(), "auction", "bidder", "bid-1", 520) {
+		t.Fatalf("Expected a bid reaching the buy now price to close the auction")
+	}
+
+	expected := bson.M{"$set": bson.M{"status": auction_entity.Completed,
+		"winner_user_id": "bidder", "winner_bid_id": "bid-1", "final_price": 520.0}}
+	if len(updates) != 1 || !reflect.DeepEqual(updates[0], expected) {
+		t.Errorf("Expected a single update completing the auction for the bid, got %v", updates)
+	}
+	if repo.isTracked("auction") {
+		t.Errorf("Expected the auction to leave active tracking")
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected a close event, got %v", events)
+	}
+
+	// O fechamento tem precedência: o lance na janela final não prorroga
+	if _, extended := repo.ExtendIfClosing(context.Background(), "auction"); extended {
+		t.Errorf("Expected the instantly closed auction not to be extended")
+	}
+}
+
+func TestBidBelowThresholdDoesNotCloseInstantly(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.instantClose = true
+	stubFindAuctionById(repo,
+		&auction_entity.Auction{Id: "auction", Status: auction_entity.Active,
+			BuyNowPrice: 500, EndTime: time.Now().Add(time.Hour)},
+		&auction_entity.Auction{Id: "no-buy-now", Status: auction_entity.Active,
+			EndTime: time.Now().Add(time.Hour)},
+		&auction_entity.Auction{Id: "below-reserve", Status: auction_entity.Active,
+			BuyNowPrice: 500, ReservePrice: 800, EndTime: time.Now().Add(time.Hour)})
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		t.Fatalf("Expected no close for %v", filter["_id"])
+		return 0, nil
+	}
+
+	for _, id := range []string{"auction", "no-buy-now", "below-reserve"} {
+		repo.trackAuction(id, time.Now().Add(time.Hour), "books")
+	}
+
+	if repo.CloseIfInstant(context.Background(), "auction", "bidder", "bid-1", 499) {
+		t.Errorf("Expected a bid below the buy now price not to close the auction")
+	}
+	if repo.CloseIfInstant(context.Background(), "no-buy-now", "bidder", "bid-2", 1000) {
+		t.Errorf("Expected an auction without buy now price not to close instantly")
+	}
+	if repo.CloseIfInstant(context.Background(), "below-reserve", "bidder", "bid-3", 600) {
+		t.Errorf("Expected a bid below the reserve not to close the auction")
+	}
+}
+
+func TestInstantCloseDisabledByDefault(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo, &auction_entity.Auction{Id: "auction", Status: auction_entity.Active,
+		BuyNowPrice: 500, EndTime: time.Now().Add(time.Hour)})
+	repo.trackAuction("auction", time.Now().Add(time.Hour), "books")
+
+	if repo.CloseIfInstant(context.Background(), "auction", "bidder", "bid-1", 500) {
+		t.Errorf("Expected instant close to be disabled without AUCTION_INSTANT_CLOSE")
+	}
+}
@@ -0,0 +1,89 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// StatusUpdater grava a mudança de status de um leilão. Pode ser chamada
+// por várias goroutines ao mesmo tempo
+type StatusUpdater func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError
+
+// WithStatusUpdater substitui a gravação de status no banco pela função
+// informada; as atualizações em lote a chamam para cada leilão e ignoram
+// os que já mudaram de status (código auction.status_changed)
+func WithStatusUpdater(update StatusUpdater) Option {
+	return func(ar *AuctionRepository) {
+		if update == nil {
+			return
+		}
+		ar.updateAuctionStatus = update
+		ar.updateAuctionsStatus = func(
+			ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+			return updateEach(ctx, update, ids, status)
+		}
+	}
+}
+
+// Atualiza os leilões um a um, devolvendo os que mudaram de status
+func updateEach(
+	ctx context.Context,
+	update StatusUpdater,
+	ids []string,
+	status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+	var updated []string
+	for _, id := range ids {
+		if err := update(ctx, id, status); err != nil {
+			if err.Code == "auction.status_changed" {
+				continue
+			}
+			return nil, err
+		}
+		updated = append(updated, id)
+	}
+	return updated, nil
+}
+
+// NewInMemoryAuctionRepository cria um repositório sem coleção e sem a
+// goroutine de monitoramento, para que outros pacotes testem comportamentos
+// que dependem dele. Leilões criados são apenas acompanhados em memória e
+// as mudanças de status e de término são descartadas, a menos que WithStatusUpdater seja
+// informado; o fechamento pode ser disparado com CloseAndFlush. Consultas
+// ao banco (ex.: FindAuctionById) não são suportadas
+func NewInMemoryAuctionRepository(opts ...Option) *AuctionRepository {
+	repo := newAuctionRepository(nil)
+	repo.withoutMonitor = true
+	repo.clockSource = ClockLocal
+	repo.clock = systemClock{}
+	// A republicação depende de consultas ao banco
+	repo.maxRelists = 0
+
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		return nil
+	}
+	repo.findHighestBid = func(ctx context.Context, auctionId string) (*auctionWinner, error) {
+		return nil, nil
+	}
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		return 1, nil
+	}
+	repo.findAuctionById = func(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+		return nil, internal_error.NewNotFoundError("In-memory auction repository does not store auctions")
+	}
+	repo.findDueScheduledAuctions = func(ctx context.Context, now time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+		return nil, nil
+	}
+	WithStatusUpdater(func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return nil
+	})(repo)
+
+	for _, opt := range opts {
+		opt(repo)
+	}
+
+	return repo
+}
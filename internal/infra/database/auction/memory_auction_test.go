@@ -0,0 +1,55 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInMemoryAuctionRepositoryClosesExpiredAuctions(t *testing.T) {
+	clock := newFakeClock(time.Now())
+
+	var mu sync.Mutex
+	updates := map[string]auction_entity.AuctionStatus{}
+	repo := NewInMemoryAuctionRepository(
+		WithClock(clock),
+		WithDuration(time.Minute),
+		WithStatusUpdater(func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+			mu.Lock()
+			defer mu.Unlock()
+			updates[id] = status
+			return nil
+		}))
+
+	if repo.monitorStarted || repo.Collection != nil {
+		t.Fatalf("Expected no monitor and no collection")
+	}
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+		if _, err := repo.CreateAuction(context.Background(), auction); err != nil {
+			t.Fatalf("Expected auction to be created, got %v", err)
+		}
+		ids = append(ids, auction.Id)
+	}
+
+	if repo.ActiveAuctionCount() != 3 {
+		t.Fatalf("Expected 3 tracked auctions, got %d", repo.ActiveAuctionCount())
+	}
+
+	clock.Advance(2 * time.Minute)
+	repo.CloseAndFlush()
+
+	if repo.ActiveAuctionCount() != 0 {
+		t.Errorf("Expected every auction to be closed, got %d tracked", repo.ActiveAuctionCount())
+	}
+	for _, id := range ids {
+		if updates[id] != auction_entity.Completed {
+			t.Errorf("Expected auction %s to be completed, got %v", id, updates[id])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package auction
+
+import (
+	"fullcycle-auction_go/configuration/metrics"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Categoria usada quando o limite de categorias distintas é atingido
+const otherMetricCategory = "other"
+
+func newCategoryGauge() *metrics.GaugeVec {
+	return metrics.NewGaugeVec(
+		"auctions_by_category",
+		"Number of auctions tracked by this instance per category and status",
+		"category", "status")
+}
+
+// WriteMetrics escreve as métricas do repositório no formato do Prometheus,
+// incluindo os contadores de Metrics quando eles são exportáveis
+func (ar *AuctionRepository) WriteMetrics(w io.Writer) error {
+	if _, err := ar.categoryGauge.WriteTo(w); err != nil {
+		return err
+	}
+
+	if exporter, ok := ar.Metrics.(io.WriterTo); ok {
+		_, err := exporter.WriteTo(w)
+		return err
+	}
+
+	return nil
+}
+
+// Atualiza o gauge por categoria, agrupando em "other" as categorias que
+// excederem o limite para evitar explosão de séries
+func (ar *AuctionRepository) recordCategoryMetric(
+	category string, status auction_entity.AuctionStatus, delta float64) {
+	ar.metricsMutex.Lock()
+	if _, known := ar.metricCategories[category]; !known {
+		if len(ar.metricCategories) < ar.maxMetricCategories {
+			ar.metricCategories[category] = struct{}{}
+		} else {
+			category = otherMetricCategory
+		}
+	}
+	ar.metricsMutex.Unlock()
+
+	ar.categoryGauge.Add(delta, category, statusMetricLabel(status))
+}
+
+func statusMetricLabel(status auction_entity.AuctionStatus) string {
+	switch status {
+	case auction_entity.Active:
+		return "active"
+	case auction_entity.Completed:
+		return "completed"
+	case auction_entity.Draft:
+		return "draft"
+	case auction_entity.Cancelled:
+		return "cancelled"
+	case auction_entity.Paused:
+		return "paused"
+	case auction_entity.Scheduled:
+		return "scheduled"
+	default:
+		return "unknown"
+	}
+}
+
+// Lê o limite de categorias distintas de AUCTION_METRICS_MAX_CATEGORIES
+func getMaxMetricCategories() int {
+	value, err := strconv.Atoi(os.Getenv("AUCTION_METRICS_MAX_CATEGORIES"))
+	if err != nil || value <= 0 {
+		return 50
+	}
+
+	return value
+}
@@ -0,0 +1,84 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/metrics"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCategoryGaugeUpdatesOnClose(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	repo.activeAuctions["auction"] = time.Now().Add(-time.Second)
+	repo.activeCategories["auction"] = "Electronics"
+	repo.recordCategoryMetric("Electronics", auction_entity.Active, 1)
+
+	if value := repo.categoryGauge.Value("Electronics", "active"); value != 1 {
+		t.Fatalf("Expected 1 active auction in Electronics, got %v", value)
+	}
+
+	repo.checkExpiredAuctions()
+
+	if value := repo.categoryGauge.Value("Electronics", "active"); value != 0 {
+		t.Errorf("Expected 0 active auctions in Electronics after close, got %v", value)
+	}
+
+	if value := repo.categoryGauge.Value("Electronics", "completed"); value != 1 {
+		t.Errorf("Expected 1 completed auction in Electronics after close, got %v", value)
+	}
+}
+
+func TestCategoryGaugeBoundsCardinality(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.maxMetricCategories = 1
+
+	repo.recordCategoryMetric("Electronics", auction_entity.Active, 1)
+	repo.recordCategoryMetric("Books", auction_entity.Active, 1)
+
+	if value := repo.categoryGauge.Value("Books", "active"); value != 0 {
+		t.Errorf("Expected Books not to get its own series, got %v", value)
+	}
+
+	if value := repo.categoryGauge.Value(otherMetricCategory, "active"); value != 1 {
+		t.Errorf("Expected overflow category to be counted as other, got %v", value)
+	}
+}
+
+func TestAuctionCountersAdvanceOnCreateAndClose(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error { return nil }
+	auctionMetrics := metrics.NewPrometheusAuctionMetrics()
+	repo.Metrics = auctionMetrics
+
+	for i := 0; i < 2; i++ {
+		auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+		if _, err := repo.CreateAuction(context.Background(), auction); err != nil {
+			t.Fatalf("Expected auction to be created, got %v", err)
+		}
+	}
+
+	if created, active := auctionMetrics.Created.Value(), auctionMetrics.Active.Value(); created != 2 || active != 2 {
+		t.Fatalf("Expected 2 created and 2 active auctions, got %v and %v", created, active)
+	}
+
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+	repo.checkExpiredAuctions()
+
+	if closed, active := auctionMetrics.Closed.Value(), auctionMetrics.Active.Value(); closed != 1 || active != 2 {
+		t.Errorf("Expected 1 closed and 2 active auctions, got %v and %v", closed, active)
+	}
+
+	var builder strings.Builder
+	if err := repo.WriteMetrics(&builder); err != nil {
+		t.Fatalf("Failed to write metrics: %v", err)
+	}
+
+	for _, expected := range []string{"auctions_created_total 2", "auctions_closed_total 1", "active_auctions 2"} {
+		if !strings.Contains(builder.String(), expected) {
+			t.Errorf("Expected metrics output to contain %q, got %q", expected, builder.String())
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Quantos documentos cada atualização de MigrateEndTimes preenche
+const migrateEndTimesBatchSize = 500
+
+// MigrateEndTimes grava end_time nos documentos criados antes de o término
+// ser persistido, calculando-o como em auctionEndTime (timestamp mais a
+// duração do leilão ou AUCTION_INTERVAL). Rascunhos e agendados ficam sem
+// end_time, que é gravado ao publicá-los ou iniciá-los. Os documentos são
+// atualizados em lotes; rodar de novo não altera nada. Retorna quantos
+// documentos foram atualizados
+func (ar *AuctionRepository) MigrateEndTimes(ctx context.Context) (int64, *internal_error.InternalError) {
+	filter := legacyEndTimeFilter()
+	update := migrateEndTimeUpdate(ar.AuctionDuration())
+
+	var migrated int64
+	for {
+		ids, err := ar.findAuctionIds(ctx, filter, options.Find().SetLimit(migrateEndTimesBatchSize))
+		if err != nil {
+			return migrated, ar.migrateEndTimesError(err, migrated)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		batchFilter := legacyEndTimeFilter()
+		batchFilter["_id"] = bson.M{"$in": ids}
+		result, err := ar.Collection.UpdateMany(ctx, batchFilter, update)
+		if err != nil {
+			return migrated, ar.migrateEndTimesError(err, migrated)
+		}
+		migrated += result.ModifiedCount
+
+		// Um lote que não mudou nada não pode ser repetido para sempre
+		if len(ids) < migrateEndTimesBatchSize || result.ModifiedCount == 0 {
+			break
+		}
+	}
+
+	ar.logger.Info("Auction end times migrated", zap.Int64("auctions", migrated))
+	return migrated, nil
+}
+
+// Documentos sem end_time que já deveriam tê-lo
+func legacyEndTimeFilter() bson.M {
+	return bson.M{
+		"end_time": bson.M{"$exists": false},
+		"status":   bson.M{"$nin": bson.A{auction_entity.Draft, auction_entity.Scheduled}},
+	}
+}
+
+// Atualização com pipeline: end_time = timestamp + duração (em segundos),
+// usando defaultDuration quando o documento não tem duração própria
+func migrateEndTimeUpdate(defaultDuration time.Duration) mongo.Pipeline {
+	duration := bson.M{"$ifNull": bson.A{"$duration", int64(defaultDuration)}}
+	seconds := bson.M{"$trunc": bson.M{"$divide": bson.A{duration, int64(time.Second)}}}
+
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"end_time": bson.M{"$toLong": bson.M{"$add": bson.A{"$timestamp", seconds}}},
+		}}},
+	}
+}
+
+func (ar *AuctionRepository) migrateEndTimesError(err error, migrated int64) *internal_error.InternalError {
+	ar.logger.Error("Error trying to migrate auction end times", err, zap.Int64("migrated", migrated))
+	return internal_error.NewInternalServerError("Error trying to migrate auction end times")
+}
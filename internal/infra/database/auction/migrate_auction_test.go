@@ -0,0 +1,68 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestLegacyEndTimeFilterSkipsUnpublishedAuctions(t *testing.T) {
+	statuses := legacyEndTimeFilter()["status"].(bson.M)["$nin"].(bson.A)
+
+	if len(statuses) != 2 || statuses[0] != auction_entity.Draft || statuses[1] != auction_entity.Scheduled {
+		t.Errorf("Expected drafts and scheduled auctions to keep no end time, got %v", statuses)
+	}
+}
+
+func TestMigrateEndTimes(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database, WithDuration(time.Hour))
+	defer repo.cancelFunc()
+
+	created := time.Now().Add(-30 * time.Minute).Truncate(time.Second)
+	persisted := created.Add(5 * time.Hour).Unix()
+	seeds := []interface{}{
+		AuctionEntityMongo{Id: "legacy", Status: auction_entity.Active, Timestamp: created.Unix()},
+		AuctionEntityMongo{Id: "legacy-custom", Status: auction_entity.Completed, Timestamp: created.Unix(),
+			Duration: 10 * time.Minute},
+		AuctionEntityMongo{Id: "persisted", Status: auction_entity.Active, Timestamp: created.Unix(), EndTime: persisted},
+		AuctionEntityMongo{Id: "draft", Status: auction_entity.Draft, Timestamp: created.Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seeds); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	migrated, err := repo.MigrateEndTimes(ctx)
+	if err != nil {
+		t.Fatalf("Failed to migrate end times: %v", err)
+	}
+	if migrated != 2 {
+		t.Errorf("Expected 2 legacy auctions to be migrated, got %d", migrated)
+	}
+
+	expected := map[string]int64{
+		"legacy":        created.Add(time.Hour).Unix(),
+		"legacy-custom": created.Add(10 * time.Minute).Unix(),
+		"persisted":     persisted,
+		"draft":         0,
+	}
+	for id, endTime := range expected {
+		var document AuctionEntityMongo
+		if err := repo.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&document); err != nil {
+			t.Fatalf("Failed to load %s: %v", id, err)
+		}
+		if document.EndTime != endTime {
+			t.Errorf("%s: expected end_time %d, got %d", id, endTime, document.EndTime)
+		}
+	}
+
+	again, err := repo.MigrateEndTimes(ctx)
+	if err != nil || again != 0 {
+		t.Errorf("Expected a second run to change nothing, got %d (%v)", again, err)
+	}
+}
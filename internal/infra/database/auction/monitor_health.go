@@ -0,0 +1,58 @@
+package auction
+
+import (
+	"time"
+)
+
+// Quantos intervalos de verificação sem varredura tornam o monitor suspeito
+const monitorStaleTicks = 3
+
+// MonitorHealth resume o estado do monitor de leilões para checagens de
+// liveness
+type MonitorHealth struct {
+	Running        bool      `json:"running"`
+	LastTick       time.Time `json:"last_tick"`
+	ActiveAuctions int       `json:"active_auctions"`
+	// Verdadeiro quando o monitor está rodando, mas não varre há mais de
+	// monitorStaleTicks intervalos de verificação
+	Stale bool `json:"stale"`
+}
+
+// Healthy indica se o monitor está rodando e varrendo normalmente
+func (h MonitorHealth) Healthy() bool {
+	return h.Running && !h.Stale
+}
+
+// Health informa se o monitor desta instância está vivo, quando ele varreu
+// pela última vez e quantos leilões estão sendo acompanhados
+func (ar *AuctionRepository) Health() MonitorHealth {
+	running := ar.monitorStarted
+	if running {
+		select {
+		case <-ar.monitorDone:
+			running = false
+		default:
+		}
+	}
+
+	ar.healthMutex.Lock()
+	lastTick, reference := ar.lastTick, ar.lastTick
+	ar.healthMutex.Unlock()
+	if reference.IsZero() {
+		reference = ar.monitorStartedAt
+	}
+
+	return MonitorHealth{
+		Running:        running,
+		LastTick:       lastTick,
+		ActiveAuctions: ar.ActiveAuctionCount(),
+		Stale:          running && time.Since(reference) > monitorStaleTicks*ar.checkInterval,
+	}
+}
+
+// Registra o horário da varredura que acabou de terminar
+func (ar *AuctionRepository) recordTick() {
+	ar.healthMutex.Lock()
+	ar.lastTick = time.Now()
+	ar.healthMutex.Unlock()
+}
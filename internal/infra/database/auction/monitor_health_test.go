@@ -0,0 +1,60 @@
+package auction
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHealthRecordsLastTick(t *testing.T) {
+	os.Setenv("AUCTION_CHECK_INTERVAL", "100ms")
+	defer os.Unsetenv("AUCTION_CHECK_INTERVAL")
+
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "health_test"))
+	defer repo.Close()
+
+	started := time.Now()
+	deadline := started.Add(time.Second)
+	for repo.Health().LastTick.IsZero() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	health := repo.Health()
+	if !health.LastTick.After(started) {
+		t.Fatalf("Expected a tick to be recorded after the check cycle, got %v", health.LastTick)
+	}
+
+	if !health.Healthy() {
+		t.Errorf("Expected a running monitor to be healthy, got %+v", health)
+	}
+
+	repo.Close()
+	if repo.Health().Running {
+		t.Errorf("Expected the monitor not to be running after Close")
+	}
+}
+
+func TestHealthReportsStaleMonitor(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.monitorStarted = true
+	repo.checkInterval = time.Second
+	repo.trackAuction("auction", time.Now().Add(time.Hour), "books")
+
+	repo.lastTick = time.Now().Add(-2 * time.Second)
+	if health := repo.Health(); health.Stale || health.ActiveAuctions != 1 {
+		t.Errorf("Expected a recent tick not to be stale, got %+v", health)
+	}
+
+	repo.lastTick = time.Now().Add(-4 * time.Second)
+	if health := repo.Health(); !health.Stale || health.Healthy() {
+		t.Errorf("Expected a tick older than 3 intervals to be stale, got %+v", health)
+	}
+}
+
+func TestHealthWithoutMonitor(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	if health := repo.Health(); health.Running || health.Healthy() {
+		t.Errorf("Expected a repository without monitor not to be healthy, got %+v", health)
+	}
+}
@@ -0,0 +1,45 @@
+package auction
+
+import (
+	"os"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Registro, no processo, das coleções que já possuem um monitor ativo.
+// Evita que duas instâncias do repositório fechem os mesmos leilões
+var (
+	monitorRegistry      = make(map[string]int)
+	monitorRegistryMutex sync.Mutex
+)
+
+func monitorNamespace(collection *mongo.Collection) string {
+	return collection.Database().Name() + "." + collection.Name()
+}
+
+// Registra um monitor para a coleção; retorna false se já existir um e
+// AUCTION_ALLOW_MULTIPLE_MONITORS não estiver habilitado
+func registerMonitor(namespace string) bool {
+	monitorRegistryMutex.Lock()
+	defer monitorRegistryMutex.Unlock()
+
+	if monitorRegistry[namespace] > 0 && os.Getenv("AUCTION_ALLOW_MULTIPLE_MONITORS") != "true" {
+		return false
+	}
+
+	monitorRegistry[namespace]++
+	return true
+}
+
+func releaseMonitor(namespace string) {
+	monitorRegistryMutex.Lock()
+	defer monitorRegistryMutex.Unlock()
+
+	if monitorRegistry[namespace] <= 1 {
+		delete(monitorRegistry, namespace)
+		return
+	}
+
+	monitorRegistry[namespace]--
+}
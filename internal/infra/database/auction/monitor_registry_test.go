@@ -0,0 +1,85 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Cria um banco sem conexão real; o driver só conecta na primeira operação
+func setupDisconnectedDatabase(t *testing.T, name string) *mongo.Database {
+	t.Helper()
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1").
+		SetServerSelectionTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to create mongo client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+
+	return client.Database(name)
+}
+
+func waitForMonitorRelease(t *testing.T, namespace string) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		monitorRegistryMutex.Lock()
+		_, registered := monitorRegistry[namespace]
+		monitorRegistryMutex.Unlock()
+
+		if !registered {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("Expected monitor for %s to be released", namespace)
+}
+
+func TestMonitorRegistryRefusesSecondMonitor(t *testing.T) {
+	database := setupDisconnectedDatabase(t, "registry_guard_test")
+
+	first := NewAuctionRepository(database)
+	second := NewAuctionRepository(database)
+
+	if !first.monitorStarted {
+		t.Fatalf("Expected first repository to start its monitor")
+	}
+
+	if second.monitorStarted {
+		t.Errorf("Expected second repository on the same collection not to start a monitor")
+	}
+
+	first.cancelFunc()
+	second.cancelFunc()
+	waitForMonitorRelease(t, monitorNamespace(first.Collection))
+
+	third := NewAuctionRepository(database)
+	defer third.cancelFunc()
+
+	if !third.monitorStarted {
+		t.Errorf("Expected a new monitor to start after the previous one stopped")
+	}
+}
+
+func TestMonitorRegistryAllowsMultipleMonitorsWhenConfigured(t *testing.T) {
+	os.Setenv("AUCTION_ALLOW_MULTIPLE_MONITORS", "true")
+	defer os.Unsetenv("AUCTION_ALLOW_MULTIPLE_MONITORS")
+
+	database := setupDisconnectedDatabase(t, "registry_override_test")
+
+	first := NewAuctionRepository(database)
+	defer first.cancelFunc()
+	second := NewAuctionRepository(database)
+	defer second.cancelFunc()
+
+	if !first.monitorStarted || !second.monitorStarted {
+		t.Errorf("Expected both monitors to start when multiple monitors are allowed")
+	}
+}
@@ -0,0 +1,127 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// PauseAuction suspende um leilão ativo (ex.: anúncio contestado) com o
+// status Paused, gravando quando a pausa começou. Enquanto pausado ele fica
+// fora do monitoramento e não é fechado, mesmo depois do término previsto
+func (ar *AuctionRepository) PauseAuction(ctx context.Context, id string) *internal_error.InternalError {
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	notActive := internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s is not active", id)).WithCode("auction.not_active")
+	if auctionEntity.Status != auction_entity.Active {
+		return notActive
+	}
+
+	// O status e o mapa mudam na mesma transição, para que o monitor não
+	// feche o leilão no meio da pausa
+	pausedAt := ar.now()
+	category := auctionEntity.Category
+	var wasTracked bool
+	applied, err := ar.transitionWith(ctx, id,
+		func() bool {
+			// Fora do mapa o leilão já está sendo fechado; na varredura pelo
+			// banco o mapa não é usado
+			return ar.isTrackedLocked(id) || ar.sweepStrategy == SweepDatabase
+		},
+		func(ctx context.Context) (bool, *internal_error.InternalError) {
+			filter := bson.M{"_id": id, "status": auction_entity.Active}
+			update := bson.M{"$set": bson.M{"status": auction_entity.Paused, "paused_at": pausedAt.Unix()}}
+			matched, updateErr := ar.updateAuction(ctx, filter, update)
+			if updateErr != nil {
+				ar.logger.Error("Error trying to pause auction", updateErr, zap.String("auction_id", id))
+				return false, internal_error.NewInternalServerError("Error trying to pause auction")
+			}
+			return matched > 0, nil
+		},
+		func() {
+			if trackedCategory, tracked := ar.untrackLocked(id); tracked {
+				category, wasTracked = trackedCategory, true
+			}
+		})
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return notActive
+	}
+
+	if wasTracked {
+		ar.recordCategoryMetric(category, auction_entity.Active, -1)
+	}
+	ar.recordCategoryMetric(category, auction_entity.Paused, 1)
+
+	ar.logger.Info("Auction paused",
+		zap.String("auction_id", id),
+		zap.Stringer("status", auction_entity.Paused))
+	return nil
+}
+
+// ResumeAuction volta a correr um leilão pausado, adiando o término pelo
+// tempo que ele ficou pausado, e retorna o novo término
+func (ar *AuctionRepository) ResumeAuction(
+	ctx context.Context, id string) (time.Time, *internal_error.InternalError) {
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	notPaused := internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s is not paused", id)).WithCode("auction.not_paused")
+	if auctionEntity.Status != auction_entity.Paused {
+		return time.Time{}, notPaused
+	}
+
+	now := ar.now()
+	endTime := auctionEntity.EndTime
+	if !auctionEntity.PausedAt.IsZero() && now.After(auctionEntity.PausedAt) {
+		endTime = endTime.Add(now.Sub(auctionEntity.PausedAt))
+	}
+
+	applied, err := ar.transitionWith(ctx, id, nil,
+		func(ctx context.Context) (bool, *internal_error.InternalError) {
+			filter := bson.M{"_id": id, "status": auction_entity.Paused}
+			update := bson.M{
+				"$set":   bson.M{"status": auction_entity.Active, "end_time": endTime.Unix()},
+				"$unset": bson.M{"paused_at": ""},
+			}
+			matched, updateErr := ar.updateAuction(ctx, filter, update)
+			if updateErr != nil {
+				ar.logger.Error("Error trying to resume auction", updateErr, zap.String("auction_id", id))
+				return false, internal_error.NewInternalServerError("Error trying to resume auction")
+			}
+			return matched > 0, nil
+		},
+		func() {
+			ar.activeAuctions[id] = endTime
+			ar.activeCategories[id] = auctionEntity.Category
+		})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !applied {
+		return time.Time{}, notPaused
+	}
+
+	ar.recordCategoryMetric(auctionEntity.Category, auction_entity.Active, 1)
+	ar.recordCategoryMetric(auctionEntity.Category, auction_entity.Paused, -1)
+
+	ar.logger.Info("Auction resumed",
+		zap.String("auction_id", id),
+		zap.Stringer("status", auction_entity.Active),
+		zap.Time("end_time", endTime))
+	return endTime, nil
+}
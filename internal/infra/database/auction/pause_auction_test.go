@@ -0,0 +1,119 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Aplica as atualizações de status, pausa e término no leilão em memória
+func stubAuctionUpdates(repo *AuctionRepository, auction *auction_entity.Auction) {
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		if filter["_id"] != auction.Id || filter["status"] != auction.Status {
+			return 0, nil
+		}
+
+		set, _ := update["$set"].(bson.M)
+		if status, ok := set["status"].(auction_entity.AuctionStatus); ok {
+			auction.Status = status
+		}
+		if pausedAt, ok := set["paused_at"].(int64); ok {
+			auction.PausedAt = time.Unix(pausedAt, 0)
+		}
+		if endTime, ok := set["end_time"].(int64); ok {
+			auction.EndTime = time.Unix(endTime, 0)
+		}
+		if unset, ok := update["$unset"].(bson.M); ok && unset["paused_at"] != nil {
+			auction.PausedAt = time.Time{}
+		}
+		return 1, nil
+	}
+}
+
+func TestPausedAuctionIsNotClosedUntilResumed(t *testing.T) {
+	repo := setupInMemoryRepository()
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.clock = ClockFunc(func() time.Time { return clock })
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	auction.EndTime = clock.Add(time.Minute)
+	stubFindAuctionById(repo, auction)
+	stubAuctionUpdates(repo, auction)
+	repo.trackAuction(auction.Id, auction.EndTime, auction.Category)
+
+	var closed []string
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		closed = append(closed, id)
+		return nil
+	}
+
+	if err := repo.PauseAuction(context.Background(), auction.Id); err != nil {
+		t.Fatalf("Failed to pause auction: %v", err)
+	}
+
+	if auction.Status != auction_entity.Paused || !auction.PausedAt.Equal(clock) {
+		t.Fatalf("Expected the auction to be stored as paused at %v, got %v at %v", clock, auction.Status, auction.PausedAt)
+	}
+
+	// Muito depois do término previsto o monitor não fecha o leilão pausado
+	clock = clock.Add(10 * time.Minute)
+	repo.checkExpiredAuctions()
+	if len(closed) != 0 {
+		t.Fatalf("Expected a paused auction not to be closed, got %v", closed)
+	}
+
+	endTime, err := repo.ResumeAuction(context.Background(), auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to resume auction: %v", err)
+	}
+
+	// O término é adiado pelos 10 minutos de pausa
+	expected := time.Date(2024, 1, 1, 12, 11, 0, 0, time.UTC)
+	if !endTime.Equal(expected) || !auction.EndTime.Equal(expected) {
+		t.Errorf("Expected the deadline to move to %v, got %v (stored %v)", expected, endTime, auction.EndTime)
+	}
+
+	if auction.Status != auction_entity.Active || !auction.PausedAt.IsZero() {
+		t.Errorf("Expected the auction to be active again, got %v paused at %v", auction.Status, auction.PausedAt)
+	}
+
+	repo.checkExpiredAuctions()
+	if len(closed) != 0 {
+		t.Fatalf("Expected the resumed auction not to close before its new deadline, got %v", closed)
+	}
+
+	clock = expected.Add(time.Second)
+	repo.checkExpiredAuctions()
+	if len(closed) != 1 || closed[0] != auction.Id {
+		t.Errorf("Expected the resumed auction to close after its new deadline, got %v", closed)
+	}
+}
+
+func TestPauseAndResumeRejectInvalidStatus(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	completed, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	completed.Status = auction_entity.Completed
+	active, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	stubFindAuctionById(repo, completed, active)
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		t.Fatalf("Expected no update for an invalid pause or resume")
+		return 0, nil
+	}
+
+	if err := repo.PauseAuction(context.Background(), completed.Id); err == nil || err.Code != "auction.not_active" {
+		t.Errorf("Expected auction.not_active when pausing a completed auction, got %v", err)
+	}
+
+	if _, err := repo.ResumeAuction(context.Background(), active.Id); err == nil || err.Code != "auction.not_paused" {
+		t.Errorf("Expected auction.not_paused when resuming an active auction, got %v", err)
+	}
+
+	if err := repo.PauseAuction(context.Background(), "missing"); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found when pausing an unknown auction, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// PublishAuction torna ativo um leilão em rascunho; o prazo começa a
+// contar a partir da publicação
+func (ar *AuctionRepository) PublishAuction(
+	ctx context.Context, id string) *internal_error.InternalError {
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if auctionEntity.Status != auction_entity.Draft {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("Auction %s is not a draft and cannot be published", id))
+	}
+
+	// Rascunhos não têm término gravado; o prazo conta da publicação, sem
+	// alterar a data de criação do leilão
+	published := *auctionEntity
+	published.Timestamp = time.Unix(ar.now().Unix(), 0)
+	endTime := ar.auctionEndTime(&published)
+
+	// O filtro por status garante que apenas a transição Draft -> Active ocorra
+	filter := bson.M{"_id": id, "status": auction_entity.Draft}
+	update := bson.M{"$set": bson.M{
+		"status":   auction_entity.Active,
+		"end_time": endTime.Unix(),
+	}}
+
+	matched, updateErr := ar.updateAuction(ctx, filter, update)
+	if updateErr != nil {
+		ar.logger.Error("Error trying to publish auction", updateErr, zap.String("auction_id", id))
+		return internal_error.NewInternalServerError("Error trying to publish auction")
+	}
+
+	if matched == 0 {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("Auction %s is not a draft and cannot be published", id))
+	}
+
+	ar.trackAuction(id, endTime, auctionEntity.Category)
+
+	ar.logger.Info("Auction published",
+		zap.String("auction_id", id),
+		zap.Stringer("status", auction_entity.Active),
+		zap.Time("end_time", endTime))
+
+	return nil
+}
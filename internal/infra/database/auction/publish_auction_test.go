@@ -0,0 +1,86 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCreateDraftAuctionIsNotTracked(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	var inserted []*AuctionEntityMongo
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		inserted = append(inserted, auction)
+		return nil
+	}
+
+	draft, err := auction_entity.CreateDraftAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	if err != nil {
+		t.Fatalf("Failed to create draft auction: %v", err)
+	}
+
+	if _, err := repo.CreateAuction(context.Background(), draft); err != nil {
+		t.Fatalf("Failed to store draft auction: %v", err)
+	}
+
+	if len(inserted) != 1 || inserted[0].Status != auction_entity.Draft {
+		t.Fatalf("Expected draft auction to be persisted with Draft status, got %+v", inserted)
+	}
+
+	if _, tracked := repo.activeAuctions[draft.Id]; tracked {
+		t.Errorf("Expected draft auction not to be tracked for auto-close")
+	}
+}
+
+func TestPublishAuctionTracksDraft(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	draft, _ := auction_entity.CreateDraftAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	stubFindAuctionById(repo, draft)
+
+	var updates, sets []bson.M
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		updates = append(updates, filter)
+		sets = append(sets, update["$set"].(bson.M))
+		return 1, nil
+	}
+
+	if err := repo.PublishAuction(context.Background(), draft.Id); err != nil {
+		t.Fatalf("Failed to publish auction: %v", err)
+	}
+
+	if len(updates) != 1 || updates[0]["status"] != auction_entity.Draft {
+		t.Fatalf("Expected a status-guarded update from Draft, got %v", updates)
+	}
+
+	// A data de criação é mantida; só o status e o término mudam
+	if _, overwritten := sets[0]["timestamp"]; overwritten || sets[0]["end_time"] == nil {
+		t.Errorf("Expected only status and end_time to be set, got %v", sets[0])
+	}
+
+	if _, tracked := repo.activeAuctions[draft.Id]; !tracked {
+		t.Errorf("Expected published auction to be tracked for auto-close")
+	}
+}
+
+func TestPublishAuctionRejectsNonDraft(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	active, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	stubFindAuctionById(repo, active)
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		t.Fatalf("Expected no update for a non-draft auction")
+		return 0, nil
+	}
+
+	if err := repo.PublishAuction(context.Background(), active.Id); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request when publishing an active auction, got %v", err)
+	}
+
+	if err := repo.PublishAuction(context.Background(), "missing"); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found when publishing a missing auction, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.uber.org/zap"
+)
+
+// FindExpiredAuctions busca no banco os leilões ainda ativos cujo término
+// (gravado ou calculado pela duração padrão) já passou, estejam ou não no
+// mapa do monitor
+func (ar *AuctionRepository) FindExpiredAuctions(
+	ctx context.Context) ([]auction_entity.Auction, *internal_error.InternalError) {
+	filter := endTimeFilter("$lt", ar.now().Add(-ar.clockSkewTolerance-ar.gracePeriod), ar.AuctionDuration())
+	filter["status"] = auction_entity.Active
+
+	return ar.findAuctionsByFilter(ctx, filter)
+}
+
+// CloseExpiredAuctions fecha de uma vez os leilões retornados por
+// FindExpiredAuctions, cobrindo os que escaparam do mapa do monitor (ex.:
+// após uma reinicialização), e retorna quantos foram fechados
+func (ar *AuctionRepository) CloseExpiredAuctions(ctx context.Context) (int, *internal_error.InternalError) {
+	expired, err := ar.findExpiredAuctions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return ar.closeAuctions(ctx, expired)
+}
+
+// Fecha os leilões expirados informados, reservando-os como em
+// transitionWith e finalizando apenas os que esta atualização concluiu
+func (ar *AuctionRepository) closeAuctions(
+	ctx context.Context, expired []auction_entity.Auction) (int, *internal_error.InternalError) {
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, len(expired))
+	for i, auction := range expired {
+		ids[i] = auction.Id
+	}
+
+	// Reserva como em transitionWith, para não disputar o fechamento com o
+	// monitor ou com outra operação em curso. O mapa vale mais que o banco:
+	// leilões cujo término no mapa ainda não passou ficam de fora
+	now := ar.now()
+	reserved := ar.reserve(ids, func(id string) bool {
+		endTime, tracked := ar.activeAuctions[id]
+		return !tracked || now.After(endTime.Add(ar.clockSkewTolerance+ar.gracePeriod))
+	})
+	if len(reserved) == 0 {
+		return 0, nil
+	}
+
+	updated, err := ar.updateAuctionsStatus(ctx, reserved, auction_entity.Completed)
+
+	ar.activeAuctionsMutex.Lock()
+	ar.releaseLocked(reserved)
+	if err != nil {
+		ar.activeAuctionsMutex.Unlock()
+		return 0, err
+	}
+
+	// Só os leilões que esta atualização concluiu são finalizados; os que
+	// mudaram de status por outra operação ficam como estão
+	completed := make(map[string]bool, len(updated))
+	for _, id := range updated {
+		completed[id] = true
+	}
+
+	trackedCategories := make(map[string]string)
+	for _, id := range updated {
+		if category, wasTracked := ar.untrackLocked(id); wasTracked {
+			trackedCategories[id] = category
+		}
+	}
+	ar.activeAuctionsMutex.Unlock()
+	ar.notifyAuctionsChanged(updated...)
+
+	for _, auction := range expired {
+		if !completed[auction.Id] {
+			continue
+		}
+		category, wasTracked := trackedCategories[auction.Id]
+		if !wasTracked {
+			category = auction.Category
+		}
+		ar.finishExpiredAuction(auction.Id, category, wasTracked)
+	}
+
+	ar.logger.Info("Closed expired auctions found in the database",
+		zap.Int("auctions", len(updated)),
+		zap.Int("untracked", len(updated)-len(trackedCategories)))
+	return len(updated), nil
+}
@@ -0,0 +1,141 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCloseExpiredAuctionsClosesUntrackedAuctions(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.trackAuction("tracked", time.Now().Add(-time.Second), "books")
+
+	repo.findExpiredAuctions = func(ctx context.Context) ([]auction_entity.Auction, *internal_error.InternalError) {
+		return []auction_entity.Auction{
+			{Id: "tracked", Category: "books", Status: auction_entity.Active},
+			{Id: "stale", Category: "books", Status: auction_entity.Active},
+		}, nil
+	}
+
+	var closed []string
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		if status == auction_entity.Completed {
+			closed = append(closed, ids...)
+		}
+		return ids, nil
+	}
+
+	count, err := repo.CloseExpiredAuctions(context.Background())
+	if err != nil {
+		t.Fatalf("Expected expired auctions to be closed, got %v", err)
+	}
+
+	if count != 2 || len(closed) != 2 {
+		t.Errorf("Expected 2 auctions closed in a single update, got %d (%v)", count, closed)
+	}
+
+	if repo.isTracked("tracked") {
+		t.Errorf("Expected the tracked auction to leave the map")
+	}
+
+	if active, completed := repo.categoryGauge.Value("books", "active"),
+		repo.categoryGauge.Value("books", "completed"); active != 0 || completed != 2 {
+		t.Errorf("Expected 0 active and 2 completed auctions, got %v and %v", active, completed)
+	}
+}
+
+func TestCloseExpiredAuctionsWithoutExpired(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.findExpiredAuctions = func(ctx context.Context) ([]auction_entity.Auction, *internal_error.InternalError) {
+		return nil, nil
+	}
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		t.Errorf("Expected no update without expired auctions")
+		return nil, nil
+	}
+
+	if count, err := repo.CloseExpiredAuctions(context.Background()); err != nil || count != 0 {
+		t.Errorf("Expected nothing to be closed, got %d (%v)", count, err)
+	}
+}
+
+func TestReconcileStaleAuctionFromDatabase(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	now := time.Now()
+	seeds := []interface{}{
+		// Ativo e vencido, mas fora do mapa do monitor
+		AuctionEntityMongo{Id: "stale", Category: "books", Condition: auction_entity.New,
+			Status: auction_entity.Active, Timestamp: now.Add(-time.Hour).Unix(), EndTime: now.Add(-time.Minute).Unix()},
+		AuctionEntityMongo{Id: "running", Category: "books", Condition: auction_entity.New,
+			Status: auction_entity.Active, Timestamp: now.Unix(), EndTime: now.Add(time.Hour).Unix()},
+		AuctionEntityMongo{Id: "cancelled", Category: "books", Condition: auction_entity.New,
+			Status: auction_entity.Cancelled, Timestamp: now.Add(-time.Hour).Unix(), EndTime: now.Add(-time.Minute).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seeds); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	expired, err := repo.FindExpiredAuctions(ctx)
+	if err != nil {
+		t.Fatalf("Failed to find expired auctions: %v", err)
+	}
+	if len(expired) != 1 || expired[0].Id != "stale" {
+		t.Fatalf("Expected only the stale auction to be found, got %+v", expired)
+	}
+
+	if count, err := repo.CloseExpiredAuctions(ctx); err != nil || count != 1 {
+		t.Fatalf("Expected the stale auction to be closed, got %d (%v)", count, err)
+	}
+
+	expectedStatuses := map[string]auction_entity.AuctionStatus{
+		"stale":     auction_entity.Completed,
+		"running":   auction_entity.Active,
+		"cancelled": auction_entity.Cancelled,
+	}
+	for id, expected := range expectedStatuses {
+		var stored AuctionEntityMongo
+		if err := repo.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&stored); err != nil {
+			t.Fatalf("Failed to load auction %s: %v", id, err)
+		}
+		if stored.Status != expected {
+			t.Errorf("Expected auction %s to be %s, got %s", id, expected, stored.Status)
+		}
+	}
+}
+
+func TestCloseExpiredAuctionsSkipsExtendedAuctions(t *testing.T) {
+	repo := setupInMemoryRepository()
+	// Prorrogado depois da leitura do banco, em que o término já passou
+	repo.trackAuction("extended", time.Now().Add(time.Minute), "books")
+
+	repo.findExpiredAuctions = func(ctx context.Context) ([]auction_entity.Auction, *internal_error.InternalError) {
+		return []auction_entity.Auction{
+			{Id: "extended", Category: "books", Status: auction_entity.Active},
+			{Id: "stale", Category: "books", Status: auction_entity.Active},
+		}, nil
+	}
+
+	var closed []string
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		closed = append(closed, ids...)
+		return ids, nil
+	}
+
+	count, err := repo.CloseExpiredAuctions(context.Background())
+	if err != nil || count != 1 || len(closed) != 1 || closed[0] != "stale" {
+		t.Fatalf("Expected only the stale auction to be closed, got %d %v (%v)", count, closed, err)
+	}
+
+	if !repo.isTracked("extended") {
+		t.Errorf("Expected the extended auction to stay tracked")
+	}
+}
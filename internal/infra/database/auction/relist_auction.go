@@ -0,0 +1,80 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// Publica novamente um leilão encerrado sem lances válidos ou com a reserva
+// não atingida, copiando os dados do produto, enquanto não atingir o limite de AUCTION_MAX_RELISTS
+func (ar *AuctionRepository) relistIfUnsold(id string) {
+	if ar.maxRelists <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ar.opTimeout)
+	defer cancel()
+
+	original, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		ar.logger.Error("Error trying to load auction for relisting", err, zap.String("auction_id", id))
+		return
+	}
+
+	if original.RelistCount >= ar.maxRelists {
+		return
+	}
+
+	_, beforeEnd, countErr := ar.countAuctionBids(ctx, id, ar.auctionEndTime(original))
+	if countErr != nil {
+		ar.logger.Error("Error trying to count auction bids for relisting", countErr,
+			zap.String("auction_id", id))
+		return
+	}
+	// Lances abaixo da reserva não vendem o produto
+	if beforeEnd > 0 && !original.ReserveNotMet {
+		return
+	}
+
+	category := original.CategoryDisplay
+	if category == "" {
+		category = original.Category
+	}
+
+	relisted, createErr := auction_entity.CreateAuction(
+		original.SellerId, original.ProductName, category, original.Description, original.Condition)
+	if createErr != nil {
+		ar.logger.Error("Error trying to relist auction", createErr, zap.String("auction_id", id))
+		return
+	}
+	relisted.NotificationTemplateId = original.NotificationTemplateId
+	relisted.NotificationMetadata = original.NotificationMetadata
+	relisted.BuyNowPrice = original.BuyNowPrice
+	relisted.Duration = original.Duration
+	relisted.ReservePrice = original.ReservePrice
+	relisted.StartingBid = original.StartingBid
+	relisted.Categories = original.Categories
+	relisted.RelistedFrom = original.Id
+	relisted.RelistCount = original.RelistCount + 1
+
+	if _, err := ar.CreateAuction(ctx, relisted); err != nil {
+		return
+	}
+
+	ar.logger.Info("Unsold auction relisted",
+		zap.String("auction_id", id),
+		zap.String("relisted_auction_id", relisted.Id))
+}
+
+func getMaxRelists() int {
+	value, err := strconv.Atoi(os.Getenv("AUCTION_MAX_RELISTS"))
+	if err != nil || value < 0 {
+		return 0
+	}
+
+	return value
+}
@@ -0,0 +1,107 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+// Prepara um leilão expirado e captura os leilões inseridos pelo relist
+func setupRelistRepository(original *auction_entity.Auction, bids int64) (*AuctionRepository, *[]*AuctionEntityMongo) {
+	repo := setupInMemoryRepository()
+	repo.maxRelists = 1
+	stubFindAuctionById(repo, original)
+	stubCountAuctionBids(repo, bids, bids, nil)
+
+	inserted := &[]*AuctionEntityMongo{}
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		*inserted = append(*inserted, auction)
+		return nil
+	}
+
+	repo.trackAuction(original.Id, time.Now().Add(-time.Second), original.Category)
+	return repo, inserted
+}
+
+func newUnsoldAuction() *auction_entity.Auction {
+	return &auction_entity.Auction{
+		Id:              "original",
+		SellerId:        "seller",
+		ProductName:     "Phone",
+		Category:        "electronics",
+		CategoryDisplay: "Electronics",
+		Description:     "A phone that nobody wanted",
+		Condition:       auction_entity.Used,
+		Status:          auction_entity.Completed,
+		Timestamp:       time.Now().Add(-time.Hour),
+	}
+}
+
+func TestUnsoldAuctionIsRelistedOnce(t *testing.T) {
+	repo, inserted := setupRelistRepository(newUnsoldAuction(), 0)
+
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if len(*inserted) != 1 {
+		t.Fatalf("Expected a single relisted auction, got %d", len(*inserted))
+	}
+
+	relisted := (*inserted)[0]
+	if relisted.Id == "original" || relisted.RelistedFrom != "original" || relisted.RelistCount != 1 {
+		t.Errorf("Expected a new auction linked to the original, got %+v", relisted)
+	}
+
+	if relisted.Status != auction_entity.Active || relisted.ProductName != "Phone" || relisted.SellerId != "seller" {
+		t.Errorf("Expected an active copy of the product, got %+v", relisted)
+	}
+
+	if _, tracked := repo.activeAuctions[relisted.Id]; !tracked {
+		t.Errorf("Expected the relisted auction to be tracked")
+	}
+}
+
+func TestRelistRespectsMaxCount(t *testing.T) {
+	original := newUnsoldAuction()
+	original.RelistCount = 1
+	repo, inserted := setupRelistRepository(original, 0)
+
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if len(*inserted) != 0 {
+		t.Errorf("Expected no relist after reaching the max count, got %d", len(*inserted))
+	}
+}
+
+func TestSoldAuctionIsNotRelisted(t *testing.T) {
+	repo, inserted := setupRelistRepository(newUnsoldAuction(), 2)
+
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if len(*inserted) != 0 {
+		t.Errorf("Expected an auction with bids not to be relisted, got %d", len(*inserted))
+	}
+}
+
+func TestAuctionWithReserveNotMetIsRelisted(t *testing.T) {
+	original := newUnsoldAuction()
+	original.ReserveNotMet = true
+	repo, inserted := setupRelistRepository(original, 2)
+
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if len(*inserted) != 1 {
+		t.Errorf("Expected an auction below its reserve to be relisted, got %d", len(*inserted))
+	}
+}
+
+func TestRelistDisabledByDefault(t *testing.T) {
+	repo, inserted := setupRelistRepository(newUnsoldAuction(), 0)
+	repo.maxRelists = 0
+
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	if len(*inserted) != 0 {
+		t.Errorf("Expected no relist when disabled, got %d", len(*inserted))
+	}
+}
@@ -0,0 +1,83 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestReloadActiveAuctionsOnStartup(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	os.Setenv("AUCTION_INTERVAL", "1m")
+	os.Setenv("AUCTION_CHECK_INTERVAL", "100ms")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+	defer os.Unsetenv("AUCTION_CHECK_INTERVAL")
+
+	now := time.Now()
+	seed := []interface{}{
+		AuctionEntityMongo{Id: "running", Category: "books", Status: auction_entity.Active, Timestamp: now.Unix()},
+		AuctionEntityMongo{Id: "expired", Category: "books", Status: auction_entity.Active, Timestamp: now.Add(-time.Hour).Unix()},
+		AuctionEntityMongo{Id: "done", Category: "books", Status: auction_entity.Completed, Timestamp: now.Unix()},
+	}
+	if _, err := database.Collection("auctions").InsertMany(ctx, seed); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	repo := NewAuctionRepository(database)
+	defer repo.Close()
+
+	endTime, tracked := repo.activeAuctionsSnapshot()["running"]
+	if !tracked {
+		t.Fatalf("Expected the running auction to be tracked after startup")
+	}
+	if expected := time.Unix(now.Unix(), 0).Add(time.Minute); !endTime.Equal(expected) {
+		t.Errorf("Expected end time %v, got %v", expected, endTime)
+	}
+
+	if _, tracked := repo.activeAuctionsSnapshot()["done"]; tracked {
+		t.Errorf("Expected completed auctions not to be tracked")
+	}
+
+	// O leilão vencido é fechado na primeira varredura
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		var doc AuctionEntityMongo
+		if err := repo.Collection.FindOne(ctx, bson.M{"_id": "expired"}).Decode(&doc); err == nil &&
+			doc.Status == auction_entity.Completed {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("Expected the expired auction to be closed on the first check cycle")
+}
+
+func TestReloadActiveAuctionsToleratesUnavailableDatabase(t *testing.T) {
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "reload_test"))
+	defer repo.Close()
+
+	if !repo.monitorStarted {
+		t.Fatalf("Expected the monitor to start even if the reload fails")
+	}
+
+	if len(repo.activeAuctionsSnapshot()) != 0 {
+		t.Errorf("Expected no tracked auctions when the database is unavailable")
+	}
+}
+
+// Cópia do mapa de leilões em andamento
+func (ar *AuctionRepository) activeAuctionsSnapshot() map[string]time.Time {
+	ar.activeAuctionsMutex.RLock()
+	defer ar.activeAuctionsMutex.RUnlock()
+
+	snapshot := make(map[string]time.Time, len(ar.activeAuctions))
+	for id, endTime := range ar.activeAuctions {
+		snapshot[id] = endTime
+	}
+	return snapshot
+}
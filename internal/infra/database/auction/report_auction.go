@@ -0,0 +1,177 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindAuctionsCreatedOn retorna os leilões criados no dia informado
+// (formato 2006-01-02) no fuso horário de AUCTION_REPORT_TIMEZONE
+func (ar *AuctionRepository) FindAuctionsCreatedOn(
+	ctx context.Context, date string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	location, err := getReportLocation()
+	if err != nil {
+		ar.logger.Error("Error trying to load report timezone", err)
+		return nil, internal_error.NewInternalServerError("Error trying to load report timezone")
+	}
+
+	start, end, err := dayRange(date, location)
+	if err != nil {
+		return nil, internal_error.NewBadRequestError(
+			fmt.Sprintf("Invalid date %s, expected format 2006-01-02", date))
+	}
+
+	filter := bson.M{"timestamp": bson.M{"$gte": start.Unix(), "$lt": end.Unix()}}
+
+	return ar.findAuctionsByFilter(ctx, filter)
+}
+
+// Calcula o início e o fim (exclusivo) do dia no fuso informado
+func dayRange(date string, location *time.Location) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02", date, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	// AddDate respeita mudanças de horário de verão no fuso
+	return start, start.AddDate(0, 0, 1), nil
+}
+
+// Lê o fuso horário dos relatórios, usando UTC por padrão
+func getReportLocation() (*time.Location, error) {
+	timezone := os.Getenv("AUCTION_REPORT_TIMEZONE")
+	if timezone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(timezone)
+}
+
+// ClosingsByHour conta quantos leilões ativos terminam em cada uma das
+// próximas horas, para planejar a carga de notificações
+func (ar *AuctionRepository) ClosingsByHour(
+	ctx context.Context, hours int) ([]int, *internal_error.InternalError) {
+	if hours <= 0 {
+		return nil, internal_error.NewBadRequestError("hours must be greater than zero")
+	}
+
+	now := ar.now()
+	duration := ar.AuctionDuration()
+	window := time.Duration(hours) * time.Hour
+
+	filter := bson.M{
+		"status": auction_entity.Active,
+		"$and": bson.A{
+			endTimeFilter("$gte", now, duration),
+			endTimeFilter("$lt", now.Add(window), duration),
+		},
+	}
+
+	auctions, err := ar.findAuctionsByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	endTimes := make([]time.Time, 0, len(auctions))
+	for i := range auctions {
+		endTimes = append(endTimes, ar.auctionEndTime(&auctions[i]))
+	}
+
+	return bucketClosingsByHour(now, endTimes, hours), nil
+}
+
+// Distribui os horários de término em baldes de uma hora a partir de now
+func bucketClosingsByHour(now time.Time, endTimes []time.Time, hours int) []int {
+	buckets := make([]int, hours)
+
+	for _, endTime := range endTimes {
+		if endTime.Before(now) {
+			continue
+		}
+
+		bucket := int(endTime.Sub(now) / time.Hour)
+		if bucket < hours {
+			buckets[bucket]++
+		}
+	}
+
+	return buckets
+}
+
+type FillRateReport struct {
+	Created int64
+	Sold    int64
+	Rate    float64
+}
+
+// FillRate calcula a fração dos leilões criados no período [from, to)
+// que foram vendidos, ou seja, encerrados com vencedor e com a reserva
+// atingida
+func (ar *AuctionRepository) FillRate(
+	ctx context.Context, from, to time.Time) (*FillRateReport, *internal_error.InternalError) {
+	if to.Before(from) {
+		return nil, internal_error.NewBadRequestError("from must not be after to")
+	}
+
+	cursor, err := ar.Collection.Aggregate(ctx, fillRatePipeline(from, to))
+	if err != nil {
+		ar.logger.Error("Error trying to aggregate auction fill rate", err)
+		return nil, internal_error.NewInternalServerError("Error trying to aggregate auction fill rate")
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Created int64 `bson:"created"`
+		Sold    int64 `bson:"sold"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		ar.logger.Error("Error trying to decode auction fill rate", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode auction fill rate")
+	}
+
+	if len(results) == 0 {
+		return newFillRateReport(0, 0), nil
+	}
+
+	return newFillRateReport(results[0].Created, results[0].Sold), nil
+}
+
+func fillRatePipeline(from, to time.Time) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"timestamp": bson.M{"$gte": from.Unix(), "$lt": to.Unix()},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"created": bson.M{"$sum": 1},
+			"sold":    bson.M{"$sum": bson.M{"$cond": bson.A{soldAuctionCondition(), 1, 0}}},
+		}}},
+	}
+}
+
+// Condição de agregação de um leilão vendido: concluído com vencedor e com
+// a reserva atingida
+func soldAuctionCondition() bson.M {
+	return bson.M{"$and": bson.A{
+		bson.M{"$eq": bson.A{"$status", auction_entity.Completed}},
+		bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$winner_user_id", ""}}, ""}},
+		bson.M{"$ne": bson.A{"$reserve_not_met", true}},
+	}}
+}
+
+// Monta o relatório evitando divisão por zero quando não há leilões
+func newFillRateReport(created, sold int64) *FillRateReport {
+	report := &FillRateReport{Created: created, Sold: sold}
+	if created > 0 {
+		report.Rate = float64(sold) / float64(created)
+	}
+
+	return report
+}
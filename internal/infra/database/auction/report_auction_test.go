@@ -0,0 +1,199 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDayRangeInNonUTCTimezone(t *testing.T) {
+	location := time.FixedZone("BRT", -3*60*60)
+
+	start, end, err := dayRange("2024-01-01", location)
+	if err != nil {
+		t.Fatalf("Failed to compute day range: %v", err)
+	}
+
+	expectedStart := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+	if !start.Equal(expectedStart) {
+		t.Errorf("Expected start %v, got %v", expectedStart, start.UTC())
+	}
+
+	if !end.Equal(expectedStart.Add(24 * time.Hour)) {
+		t.Errorf("Expected end %v, got %v", expectedStart.Add(24*time.Hour), end.UTC())
+	}
+
+	// 02:30 UTC do dia 2 ainda é dia 1 no fuso local
+	lateEvening := time.Date(2024, 1, 2, 2, 30, 0, 0, time.UTC).Unix()
+	if lateEvening < start.Unix() || lateEvening >= end.Unix() {
+		t.Errorf("Expected %v to fall within the local day", time.Unix(lateEvening, 0).UTC())
+	}
+
+	// 01:00 UTC do dia 1 ainda é dia 31/12 no fuso local
+	previousDay := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC).Unix()
+	if previousDay >= start.Unix() {
+		t.Errorf("Expected %v to fall before the local day", time.Unix(previousDay, 0).UTC())
+	}
+}
+
+func TestDayRangeRejectsInvalidDate(t *testing.T) {
+	if _, _, err := dayRange("01/01/2024", time.UTC); err == nil {
+		t.Errorf("Expected invalid date format to be rejected")
+	}
+}
+
+func TestFindAuctionsCreatedOn(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	os.Setenv("AUCTION_REPORT_TIMEZONE", "America/Sao_Paulo")
+	defer os.Unsetenv("AUCTION_REPORT_TIMEZONE")
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	seeds := []interface{}{
+		AuctionEntityMongo{Id: "inside", Timestamp: time.Date(2024, 1, 2, 2, 30, 0, 0, time.UTC).Unix()},
+		AuctionEntityMongo{Id: "before", Timestamp: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC).Unix()},
+		AuctionEntityMongo{Id: "after", Timestamp: time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seeds); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	auctions, err := repo.FindAuctionsCreatedOn(ctx, "2024-01-01")
+	if err != nil {
+		t.Fatalf("Failed to find auctions: %v", err)
+	}
+
+	if len(auctions) != 1 || auctions[0].Id != "inside" {
+		t.Errorf("Expected only the auction created on the local day, got %+v", auctions)
+	}
+}
+
+func TestBucketClosingsByHour(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	endTimes := []time.Time{
+		now.Add(10 * time.Minute),
+		now.Add(59 * time.Minute),
+		now.Add(61 * time.Minute),
+		now.Add(2*time.Hour + 30*time.Minute),
+		now.Add(5 * time.Hour),
+		now.Add(-time.Minute),
+	}
+
+	buckets := bucketClosingsByHour(now, endTimes, 3)
+
+	expected := []int{2, 1, 1}
+	for i := range expected {
+		if buckets[i] != expected[i] {
+			t.Errorf("Expected bucket %d to have %d closings, got %d", i, expected[i], buckets[i])
+		}
+	}
+}
+
+func TestClosingsByHourRejectsInvalidHours(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	if _, err := repo.ClosingsByHour(context.Background(), 0); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for zero hours, got %v", err)
+	}
+}
+
+func TestClosingsByHour(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	os.Setenv("AUCTION_INTERVAL", "1h")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	now := time.Now()
+	seeds := []interface{}{
+		// Termina em ~30 minutos
+		AuctionEntityMongo{Id: "first-hour", Timestamp: now.Add(-30 * time.Minute).Unix()},
+		// Termina em ~90 minutos
+		AuctionEntityMongo{Id: "second-hour", Timestamp: now.Add(30 * time.Minute).Unix()},
+		// Já terminou
+		AuctionEntityMongo{Id: "expired", Timestamp: now.Add(-2 * time.Hour).Unix()},
+		// Termina na próxima hora, mas já está fechado
+		AuctionEntityMongo{Id: "completed", Status: auction_entity.Completed, Timestamp: now.Add(-30 * time.Minute).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seeds); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	buckets, err := repo.ClosingsByHour(ctx, 2)
+	if err != nil {
+		t.Fatalf("Failed to compute closings by hour: %v", err)
+	}
+
+	if len(buckets) != 2 || buckets[0] != 1 || buckets[1] != 1 {
+		t.Errorf("Expected buckets [1 1], got %v", buckets)
+	}
+}
+
+func TestNewFillRateReport(t *testing.T) {
+	if report := newFillRateReport(0, 0); report.Rate != 0 {
+		t.Errorf("Expected rate 0 when no auctions were created, got %v", report.Rate)
+	}
+
+	if report := newFillRateReport(4, 1); report.Rate != 0.25 {
+		t.Errorf("Expected rate 0.25, got %v", report.Rate)
+	}
+}
+
+func TestFillRatePipelineDoesNotReadBids(t *testing.T) {
+	now := time.Now()
+	for _, stage := range fillRatePipeline(now.Add(-time.Hour), now) {
+		if stage[0].Key == "$lookup" {
+			t.Errorf("Expected the fill rate to rely on the stored winner, got a $lookup stage")
+		}
+	}
+}
+
+func TestFillRateRejectsInvertedRange(t *testing.T) {
+	repo := setupInMemoryRepository()
+	now := time.Now()
+
+	if _, err := repo.FillRate(context.Background(), now, now.Add(-time.Hour)); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an inverted range, got %v", err)
+	}
+}
+
+func TestFillRate(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	now := time.Now()
+	seeds := []interface{}{
+		AuctionEntityMongo{Id: "sold", Status: auction_entity.Completed, WinnerUserId: "alice", Timestamp: now.Unix()},
+		AuctionEntityMongo{Id: "unsold", Status: auction_entity.Completed, Timestamp: now.Unix()},
+		AuctionEntityMongo{Id: "reserve", Status: auction_entity.Completed, ReserveNotMet: true, Timestamp: now.Unix()},
+		AuctionEntityMongo{Id: "running", Status: auction_entity.Active, Timestamp: now.Unix()},
+		AuctionEntityMongo{Id: "old", Status: auction_entity.Completed, WinnerUserId: "bob", Timestamp: now.Add(-48 * time.Hour).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seeds); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	report, err := repo.FillRate(ctx, now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to compute fill rate: %v", err)
+	}
+
+	if report.Created != 4 || report.Sold != 1 {
+		t.Errorf("Expected 4 created and 1 sold, got %+v", report)
+	}
+
+	if report.Rate != 0.25 {
+		t.Errorf("Expected rate of 1/4, got %v", report.Rate)
+	}
+}
@@ -0,0 +1,82 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// Torna ativos os leilões agendados cujo início já chegou e passa a
+// monitorá-los; executada a cada varredura do monitor
+func (ar *AuctionRepository) startScheduledAuctions() {
+	ctx, cancel := context.WithTimeout(ar.ctx, ar.opTimeout)
+	defer cancel()
+
+	auctions, err := ar.findDueScheduledAuctions(ctx, ar.now())
+	if err != nil || len(auctions) == 0 {
+		return
+	}
+
+	// No modo de simulação apenas registra o que seria iniciado
+	if ar.dryRun {
+		ids := make([]string, 0, len(auctions))
+		for _, auction := range auctions {
+			ids = append(ids, auction.Id)
+		}
+		ar.logger.Info("Dry run: scheduled auctions would be started", zap.Strings("auction_ids", ids))
+		return
+	}
+
+	for i := range auctions {
+		ar.startScheduledAuction(ctx, &auctions[i])
+	}
+}
+
+// Passa o leilão de Scheduled para Active; o prazo conta a partir do
+// início agendado, e não do momento da varredura
+func (ar *AuctionRepository) startScheduledAuction(ctx context.Context, auctionEntity *auction_entity.Auction) {
+	duration := auctionEntity.Duration
+	if duration <= 0 {
+		duration = ar.AuctionDuration()
+	}
+	endTime := auctionEntity.StartTime.Add(duration)
+
+	// O filtro por status evita ativar um leilão cancelado nesse meio tempo
+	filter := bson.M{"_id": auctionEntity.Id, "status": auction_entity.Scheduled}
+	update := bson.M{"$set": bson.M{
+		"status":   auction_entity.Active,
+		"end_time": endTime.Unix(),
+	}}
+
+	matched, err := ar.updateAuction(ctx, filter, update)
+	if err != nil {
+		ar.logger.Error("Error trying to start scheduled auction", err,
+			zap.String("auction_id", auctionEntity.Id))
+		return
+	}
+	if matched == 0 {
+		return
+	}
+
+	ar.trackAuction(auctionEntity.Id, endTime, auctionEntity.Category)
+	ar.notifyAuctionsChanged(auctionEntity.Id)
+	ar.Metrics.SetActiveAuctions(ar.ActiveAuctionCount())
+
+	ar.logger.Info("Scheduled auction started",
+		zap.String("auction_id", auctionEntity.Id),
+		zap.Stringer("status", auction_entity.Active),
+		zap.Time("end_time", endTime))
+}
+
+// Busca no banco os leilões agendados com início até now
+func (ar *AuctionRepository) findDueScheduledAuctionsImpl(
+	ctx context.Context, now time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+	return ar.findAuctionsByFilter(ctx, bson.M{
+		"status":     auction_entity.Scheduled,
+		"start_time": bson.M{"$lte": now.Unix()},
+	})
+}
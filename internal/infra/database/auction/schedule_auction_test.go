@@ -0,0 +1,153 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Cria um leilão agendado para começar start depois da criação
+func newScheduledAuction(t *testing.T, start time.Duration) *auction_entity.Auction {
+	t.Helper()
+
+	auction, err := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	if err != nil {
+		t.Fatalf("Failed to create auction entity: %v", err)
+	}
+	if err := auction.SetStartTime(auction.Timestamp.Add(start)); err != nil {
+		t.Fatalf("Failed to schedule auction: %v", err)
+	}
+
+	return auction
+}
+
+func TestCreateScheduledAuctionIsNotTracked(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	var inserted []*AuctionEntityMongo
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		inserted = append(inserted, auction)
+		return nil
+	}
+
+	scheduled := newScheduledAuction(t, time.Hour)
+	created, err := repo.CreateAuction(context.Background(), scheduled)
+	if err != nil {
+		t.Fatalf("Failed to store scheduled auction: %v", err)
+	}
+
+	if len(inserted) != 1 || inserted[0].Status != auction_entity.Scheduled ||
+		inserted[0].StartTime != scheduled.StartTime.Unix() || inserted[0].EndTime != 0 {
+		t.Fatalf("Expected scheduled auction to be persisted with its start time and no end time, got %+v", inserted)
+	}
+
+	if !created.EndTime.IsZero() {
+		t.Errorf("Expected no end time before the auction starts, got %v", created.EndTime)
+	}
+
+	if repo.isTracked(scheduled.Id) {
+		t.Errorf("Expected scheduled auction not to be tracked before its start time")
+	}
+}
+
+func TestMonitorStartsScheduledAuctionAtStartTime(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.auctionDuration = 10 * time.Minute
+
+	scheduled := newScheduledAuction(t, time.Hour)
+	clock := newFakeClock(scheduled.Timestamp)
+	repo.clock = clock
+
+	// Simula a consulta ao banco: o leilão só é devolvido a partir do início
+	repo.findDueScheduledAuctions = func(ctx context.Context, now time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+		if now.Before(scheduled.StartTime) {
+			return nil, nil
+		}
+		return []auction_entity.Auction{*scheduled}, nil
+	}
+
+	var updates []bson.M
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		updates = append(updates, filter, update)
+		return 1, nil
+	}
+
+	clock.Advance(59 * time.Minute)
+	repo.sweep()
+	if repo.isTracked(scheduled.Id) || len(updates) != 0 {
+		t.Fatalf("Expected scheduled auction to wait for its start time, got updates %v", updates)
+	}
+
+	clock.Advance(time.Minute)
+	repo.sweep()
+
+	if len(updates) != 2 || updates[0]["status"] != auction_entity.Scheduled {
+		t.Fatalf("Expected a status-guarded update from Scheduled, got %v", updates)
+	}
+
+	expectedEnd := scheduled.StartTime.Add(10 * time.Minute)
+	set := updates[1]["$set"].(bson.M)
+	if set["status"] != auction_entity.Active || set["end_time"] != expectedEnd.Unix() {
+		t.Errorf("Expected auction to become Active ending at %v, got %v", expectedEnd, set)
+	}
+
+	if endTime, tracked := repo.activeAuctions[scheduled.Id]; !tracked || !endTime.Equal(expectedEnd) {
+		t.Errorf("Expected started auction to be tracked until %v, got %v (tracked=%v)", expectedEnd, endTime, tracked)
+	}
+}
+
+func TestMonitorSkipsScheduledAuctionChangedBeforeStart(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	scheduled := newScheduledAuction(t, time.Minute)
+	repo.findDueScheduledAuctions = func(ctx context.Context, now time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+		return []auction_entity.Auction{*scheduled}, nil
+	}
+	// Cancelado entre a consulta e a atualização
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		return 0, nil
+	}
+
+	repo.startScheduledAuctions()
+
+	if repo.isTracked(scheduled.Id) {
+		t.Errorf("Expected an auction no longer scheduled not to be tracked")
+	}
+}
+
+func TestStartScheduledAuctionFromDatabase(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database, WithoutMonitor(), WithDuration(time.Hour))
+
+	scheduled := newScheduledAuction(t, time.Minute)
+	if _, err := repo.CreateAuction(ctx, scheduled); err != nil {
+		t.Fatalf("Failed to create scheduled auction: %v", err)
+	}
+
+	repo.startScheduledAuctions()
+	if repo.isTracked(scheduled.Id) {
+		t.Fatalf("Expected auction not to start before its start time")
+	}
+
+	repo.clock = ClockFunc(func() time.Time { return scheduled.StartTime.Add(time.Second) })
+	repo.startScheduledAuctions()
+
+	found, err := repo.FindAuctionById(ctx, scheduled.Id)
+	if err != nil {
+		t.Fatalf("Failed to find auction: %v", err)
+	}
+	expectedEnd := time.Unix(scheduled.StartTime.Unix(), 0).Add(time.Hour)
+	if found.Status != auction_entity.Active || !found.EndTime.Equal(expectedEnd) {
+		t.Errorf("Expected auction to be Active ending at %v, got %v ending at %v", expectedEnd, found.Status, found.EndTime)
+	}
+
+	if !repo.isTracked(scheduled.Id) {
+		t.Errorf("Expected started auction to be tracked for auto-close")
+	}
+}
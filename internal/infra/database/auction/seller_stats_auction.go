@@ -0,0 +1,80 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// SellerStats resume os leilões de um vendedor para o cálculo de
+// reputação. ByStatus usa os mesmos rótulos das métricas ("completed",
+// "cancelled", ...); Sold e TotalSales contam apenas os leilões concluídos
+// com vencedor e com a reserva atingida
+type SellerStats struct {
+	ByStatus   map[string]int64 `json:"by_status"`
+	Sold       int64            `json:"sold"`
+	TotalSales float64          `json:"total_sales"`
+}
+
+// Linha da agregação: um status do vendedor
+type sellerStatusStats struct {
+	Status auction_entity.AuctionStatus `bson:"_id"`
+	Count  int64                        `bson:"count"`
+	Sold   int64                        `bson:"sold"`
+	Sales  float64                      `bson:"sales"`
+}
+
+// SellerStats conta os leilões do vendedor por status e soma o valor das
+// vendas; um vendedor sem leilões recebe tudo zerado
+func (ar *AuctionRepository) SellerStats(
+	ctx context.Context, sellerId string) (*SellerStats, *internal_error.InternalError) {
+	if sellerId == "" {
+		return nil, internal_error.NewBadRequestError("seller id is required").
+			WithCode("seller_id.empty")
+	}
+
+	cursor, err := ar.Collection.Aggregate(ctx, sellerStatsPipeline(sellerId))
+	if err != nil {
+		ar.logger.Error("Error trying to aggregate seller stats", err, zap.String("seller_id", sellerId))
+		return nil, internal_error.NewInternalServerError("Error trying to aggregate seller stats")
+	}
+	defer cursor.Close(ctx)
+
+	var rows []sellerStatusStats
+	if err := cursor.All(ctx, &rows); err != nil {
+		ar.logger.Error("Error trying to decode seller stats", err, zap.String("seller_id", sellerId))
+		return nil, internal_error.NewInternalServerError("Error trying to decode seller stats")
+	}
+
+	return newSellerStats(rows), nil
+}
+
+func sellerStatsPipeline(sellerId string) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"seller_id": sellerId}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$status",
+			"count": bson.M{"$sum": 1},
+			"sold":  bson.M{"$sum": bson.M{"$cond": bson.A{soldAuctionCondition(), 1, 0}}},
+			"sales": bson.M{"$sum": bson.M{"$cond": bson.A{
+				soldAuctionCondition(), bson.M{"$ifNull": bson.A{"$final_price", 0}}, 0,
+			}}},
+		}}},
+	}
+}
+
+// Junta as linhas por status no resumo do vendedor
+func newSellerStats(rows []sellerStatusStats) *SellerStats {
+	stats := &SellerStats{ByStatus: make(map[string]int64)}
+	for _, row := range rows {
+		stats.ByStatus[statusMetricLabel(row.Status)] += row.Count
+		stats.Sold += row.Sold
+		stats.TotalSales += row.Sales
+	}
+
+	return stats
+}
@@ -0,0 +1,70 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+func TestNewSellerStatsWithoutAuctions(t *testing.T) {
+	stats := newSellerStats(nil)
+
+	if len(stats.ByStatus) != 0 || stats.Sold != 0 || stats.TotalSales != 0 {
+		t.Errorf("Expected zeroed stats, got %+v", stats)
+	}
+}
+
+func TestSellerStatsRequiresSeller(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	if _, err := repo.SellerStats(context.Background(), ""); err == nil || err.Code != "seller_id.empty" {
+		t.Errorf("Expected seller_id.empty, got %v", err)
+	}
+}
+
+func TestSellerStats(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	now := time.Now().Unix()
+	seeds := []interface{}{
+		AuctionEntityMongo{Id: "sold-1", SellerId: "seller", Status: auction_entity.Completed,
+			WinnerUserId: "alice", FinalPrice: 100, Timestamp: now},
+		AuctionEntityMongo{Id: "sold-2", SellerId: "seller", Status: auction_entity.Completed,
+			WinnerUserId: "bob", FinalPrice: 250, Timestamp: now},
+		AuctionEntityMongo{Id: "unsold", SellerId: "seller", Status: auction_entity.Completed, Timestamp: now},
+		AuctionEntityMongo{Id: "below-reserve", SellerId: "seller", Status: auction_entity.Completed,
+			ReserveNotMet: true, Timestamp: now},
+		AuctionEntityMongo{Id: "cancelled", SellerId: "seller", Status: auction_entity.Cancelled, Timestamp: now},
+		AuctionEntityMongo{Id: "running", SellerId: "seller", Status: auction_entity.Active, Timestamp: now},
+		AuctionEntityMongo{Id: "other", SellerId: "other-seller", Status: auction_entity.Completed,
+			WinnerUserId: "alice", FinalPrice: 999, Timestamp: now},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seeds); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	stats, err := repo.SellerStats(ctx, "seller")
+	if err != nil {
+		t.Fatalf("Failed to compute seller stats: %v", err)
+	}
+
+	if stats.ByStatus["completed"] != 4 || stats.ByStatus["cancelled"] != 1 || stats.ByStatus["active"] != 1 {
+		t.Errorf("Unexpected counts by status %v", stats.ByStatus)
+	}
+	if stats.Sold != 2 || stats.TotalSales != 350 {
+		t.Errorf("Expected 2 sales totalling 350, got %d totalling %v", stats.Sold, stats.TotalSales)
+	}
+
+	empty, err := repo.SellerStats(ctx, "new-seller")
+	if err != nil {
+		t.Fatalf("Failed to compute seller stats: %v", err)
+	}
+	if len(empty.ByStatus) != 0 || empty.Sold != 0 || empty.TotalSales != 0 {
+		t.Errorf("Expected zeroed stats for a seller without auctions, got %+v", empty)
+	}
+}
@@ -0,0 +1,79 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// SweepStrategy define como o monitor encontra os leilões expirados
+type SweepStrategy string
+
+const (
+	// SweepInMemory usa o mapa de leilões em andamento desta instância
+	SweepInMemory SweepStrategy = "memory"
+	// SweepDatabase consulta o MongoDB diretamente, ignorando o mapa local,
+	// o que permite várias instâncias sem estado compartilhado
+	SweepDatabase SweepStrategy = "database"
+)
+
+// Executa uma varredura de acordo com a estratégia configurada
+func (ar *AuctionRepository) sweep() {
+	if ar.clockSource == ClockDatabase {
+		ar.syncServerClock(ar.ctx)
+	}
+
+	ar.startScheduledAuctions()
+
+	switch ar.sweepStrategy {
+	case SweepDatabase:
+		ar.closeExpiredAuctionsFromDatabase()
+	default:
+		ar.checkExpiredAuctions()
+	}
+}
+
+// Fecha todos os leilões ativos cujo prazo já terminou segundo o banco,
+// finalizando cada um (vencedor, evento e métricas) como a varredura em
+// memória; no modo de simulação apenas registra o que seria fechado
+func (ar *AuctionRepository) closeExpiredAuctionsFromDatabase() *internal_error.InternalError {
+	ctx, cancel := context.WithTimeout(ar.ctx, ar.opTimeout)
+	defer cancel()
+
+	expired, err := ar.findExpiredAuctions(ctx)
+	if err != nil {
+		ar.logger.Error("Error trying to find expired auctions in the database", err)
+		return err
+	}
+
+	if ar.dryRun {
+		if len(expired) > 0 {
+			ids := make([]string, len(expired))
+			for i, auction := range expired {
+				ids[i] = auction.Id
+			}
+			ar.logger.Info("Dry run: expired auctions would be closed",
+				zap.Strings("auction_ids", ids))
+		}
+		return nil
+	}
+
+	if _, err := ar.closeAuctions(ctx, expired); err != nil {
+		ar.logger.Error("Error trying to close expired auctions from database", err)
+		return err
+	}
+
+	return nil
+}
+
+// Lê a estratégia de varredura da variável de ambiente AUCTION_SWEEP_STRATEGY
+func getSweepStrategy() SweepStrategy {
+	switch strategy := SweepStrategy(os.Getenv("AUCTION_SWEEP_STRATEGY")); strategy {
+	case SweepInMemory, SweepDatabase:
+		return strategy
+	default:
+		return SweepInMemory
+	}
+}
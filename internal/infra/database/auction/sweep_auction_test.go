@@ -0,0 +1,135 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestGetSweepStrategy(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected SweepStrategy
+	}{
+		{"", SweepInMemory},
+		{"memory", SweepInMemory},
+		{"database", SweepDatabase},
+		{"invalid", SweepInMemory},
+	}
+
+	for _, tc := range testCases {
+		os.Setenv("AUCTION_SWEEP_STRATEGY", tc.value)
+		if strategy := getSweepStrategy(); strategy != tc.expected {
+			t.Errorf("For %q expected strategy %s, got %s", tc.value, tc.expected, strategy)
+		}
+	}
+	os.Unsetenv("AUCTION_SWEEP_STRATEGY")
+}
+
+func TestDatabaseSweepFinalizesClosedAuctions(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.findExpiredAuctions = func(ctx context.Context) ([]auction_entity.Auction, *internal_error.InternalError) {
+		return []auction_entity.Auction{{Id: "expired", Category: "books", Status: auction_entity.Active}}, nil
+	}
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		return ids, nil
+	}
+	stubFindAuctionById(repo, &auction_entity.Auction{Id: "expired", Category: "books", Status: auction_entity.Completed})
+
+	var winnerLookups, events []string
+	repo.findHighestBid = func(ctx context.Context, auctionId string) (*auctionWinner, error) {
+		winnerLookups = append(winnerLookups, auctionId)
+		return nil, nil
+	}
+	repo.OnAuctionClosed = func(event AuctionClosedEvent) {
+		events = append(events, event.AuctionId)
+	}
+
+	if err := repo.closeExpiredAuctionsFromDatabase(); err != nil {
+		t.Fatalf("Expected expired auctions to be closed, got %v", err)
+	}
+
+	if len(winnerLookups) != 1 || len(events) != 1 || events[0] != "expired" {
+		t.Errorf("Expected the closed auction to get a winner and a close event, got %v and %v",
+			winnerLookups, events)
+	}
+	if completed := repo.categoryGauge.Value("books", "completed"); completed != 1 {
+		t.Errorf("Expected 1 completed auction, got %v", completed)
+	}
+}
+
+func TestDatabaseSweepDryRunOnlyLogs(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	defer logger.Replace(zap.New(core))()
+
+	repo := setupInMemoryRepository()
+	repo.dryRun = true
+	repo.findExpiredAuctions = func(ctx context.Context) ([]auction_entity.Auction, *internal_error.InternalError) {
+		return []auction_entity.Auction{{Id: "expired", Category: "books", Status: auction_entity.Active}}, nil
+	}
+	repo.updateAuctionsStatus = func(ctx context.Context, ids []string, status auction_entity.AuctionStatus) ([]string, *internal_error.InternalError) {
+		t.Errorf("Expected no status update in dry run, got one for %v", ids)
+		return ids, nil
+	}
+
+	if err := repo.closeExpiredAuctionsFromDatabase(); err != nil {
+		t.Fatalf("Expected the dry run to succeed, got %v", err)
+	}
+
+	if entries := logs.FilterMessage("Dry run: expired auctions would be closed").All(); len(entries) != 1 {
+		t.Errorf("Expected one dry run log entry, got %d", len(entries))
+	}
+}
+
+func TestCloseExpiredAuctionsFromDatabase(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	os.Setenv("AUCTION_INTERVAL", "1m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	// Insere documentos diretamente, sem passar pelo mapa desta instância
+	expired := AuctionEntityMongo{
+		Id:        "expired-auction",
+		Status:    auction_entity.Active,
+		Timestamp: time.Now().Add(-2 * time.Minute).Unix(),
+	}
+	running := AuctionEntityMongo{
+		Id:        "running-auction",
+		Status:    auction_entity.Active,
+		Timestamp: time.Now().Unix(),
+	}
+	if _, err := repo.Collection.InsertMany(ctx, []interface{}{expired, running}); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	if err := repo.closeExpiredAuctionsFromDatabase(); err != nil {
+		t.Fatalf("Failed to close expired auctions: %v", err)
+	}
+
+	expiredFromDB, err := repo.FindAuctionById(ctx, expired.Id)
+	if err != nil {
+		t.Fatalf("Failed to find expired auction: %v", err)
+	}
+	if expiredFromDB.Status != auction_entity.Completed {
+		t.Errorf("Expected expired auction to be Completed, got %v", expiredFromDB.Status)
+	}
+
+	runningFromDB, err := repo.FindAuctionById(ctx, running.Id)
+	if err != nil {
+		t.Fatalf("Failed to find running auction: %v", err)
+	}
+	if runningFromDB.Status != auction_entity.Active {
+		t.Errorf("Expected running auction to stay Active, got %v", runningFromDB.Status)
+	}
+}
@@ -0,0 +1,155 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// TrackAuction volta a monitorar um leilão ativo que saiu do mapa de
+// leilões em andamento (ex.: após uma falha transitória)
+func (ar *AuctionRepository) TrackAuction(
+	ctx context.Context, id string) *internal_error.InternalError {
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if auctionEntity.Status != auction_entity.Active {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("Auction %s is not active and cannot be tracked", id))
+	}
+
+	endTime := ar.auctionEndTime(auctionEntity)
+	if ar.now().After(endTime) {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("Auction %s has already expired and cannot be tracked", id))
+	}
+
+	ar.trackAuction(id, endTime, auctionEntity.Category)
+
+	ar.logger.Info("Auction is being tracked again",
+		zap.String("auction_id", id),
+		zap.Time("end_time", endTime))
+
+	return nil
+}
+
+// Recarrega no mapa os leilões ativos do banco, para que um reinício não
+// deixe de fechá-los; os já vencidos são fechados na primeira varredura
+func (ar *AuctionRepository) reloadActiveAuctions() {
+	ctx, cancel := context.WithTimeout(context.Background(), ar.opTimeout)
+	defer cancel()
+
+	auctions, err := ar.findAuctionsByFilter(ctx, bson.M{"status": auction_entity.Active})
+	if err != nil {
+		ar.logger.Error("Error trying to reload active auctions, they will not be closed until tracked again", err)
+		return
+	}
+
+	for i := range auctions {
+		ar.trackAuction(auctions[i].Id, ar.auctionEndTime(&auctions[i]), auctions[i].Category)
+	}
+
+	ar.logger.Info("Reloaded active auctions", zap.Int("auctions", len(auctions)))
+}
+
+// NextClosing retorna o leilão monitorado com o menor horário de término
+func (ar *AuctionRepository) NextClosing() (id string, endTime time.Time, ok bool) {
+	ar.activeAuctionsMutex.RLock()
+	defer ar.activeAuctionsMutex.RUnlock()
+
+	for auctionId, auctionEndTime := range ar.activeAuctions {
+		if !ok || auctionEndTime.Before(endTime) {
+			id, endTime, ok = auctionId, auctionEndTime, true
+		}
+	}
+
+	return id, endTime, ok
+}
+
+// ActiveAuctionCount retorna quantos leilões estão sendo monitorados para
+// fechamento automático
+func (ar *AuctionRepository) ActiveAuctionCount() int {
+	ar.activeAuctionsMutex.RLock()
+	defer ar.activeAuctionsMutex.RUnlock()
+
+	return len(ar.activeAuctions)
+}
+
+// GetAuctionEndTime retorna o término do leilão, preferindo o valor
+// monitorado em memória (que reflete prorrogações) e, fora do mapa,
+// calculando-o a partir da criação gravada no banco
+func (ar *AuctionRepository) GetAuctionEndTime(
+	ctx context.Context, id string) (time.Time, *internal_error.InternalError) {
+	ar.activeAuctionsMutex.RLock()
+	endTime, tracked := ar.activeAuctions[id]
+	ar.activeAuctionsMutex.RUnlock()
+	if tracked {
+		return endTime, nil
+	}
+
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ar.auctionEndTime(auctionEntity), nil
+}
+
+// Término do leilão usando a duração padrão do repositório
+func (ar *AuctionRepository) auctionEndTime(auctionEntity *auction_entity.Auction) time.Time {
+	return auctionEndTime(auctionEntity, ar.AuctionDuration())
+}
+
+// Calcula o horário de término do leilão: o gravado na criação ou, sem
+// end_time, a criação mais a duração do leilão (ou a padrão informada)
+func auctionEndTime(auctionEntity *auction_entity.Auction, defaultDuration time.Duration) time.Time {
+	if !auctionEntity.EndTime.IsZero() {
+		return auctionEntity.EndTime
+	}
+
+	duration := auctionEntity.Duration
+	if duration <= 0 {
+		duration = defaultDuration
+	}
+
+	return auctionEntity.Timestamp.Add(duration)
+}
+
+// Filtro "término <op> at" que também atende documentos antigos sem
+// end_time, cujo término é derivado da criação
+func endTimeFilter(op string, at time.Time, duration time.Duration) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"end_time": bson.M{op: at.Unix()}},
+		bson.M{
+			"end_time":  bson.M{"$exists": false},
+			"timestamp": bson.M{op: at.Add(-duration).Unix()},
+		},
+	}}
+}
+
+// Registra o leilão no mapa de leilões em andamento. Um id já registrado
+// mantém o término original e gera um aviso, para que um registro repetido
+// (ex.: criação duplicada) não troque o prazo nem conte o leilão duas vezes
+func (ar *AuctionRepository) trackAuction(id string, endTime time.Time, category string) {
+	ar.activeAuctionsMutex.Lock()
+	if trackedEndTime, exists := ar.activeAuctions[id]; exists {
+		ar.activeAuctionsMutex.Unlock()
+		ar.logger.Warn("Auction is already tracked, keeping its original end time",
+			zap.String("auction_id", id),
+			zap.Time("end_time", trackedEndTime),
+			zap.Time("ignored_end_time", endTime))
+		return
+	}
+	ar.activeAuctions[id] = endTime
+	ar.activeCategories[id] = category
+	ar.activeAuctionsMutex.Unlock()
+
+	ar.recordCategoryMetric(category, auction_entity.Active, 1)
+}
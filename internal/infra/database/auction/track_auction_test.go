@@ -0,0 +1,311 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Substitui a busca do repositório por um conjunto fixo de leilões
+func stubFindAuctionById(repo *AuctionRepository, auctions ...*auction_entity.Auction) {
+	repo.findAuctionById = func(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+		for _, auction := range auctions {
+			if auction.Id == id {
+				return auction, nil
+			}
+		}
+		return nil, internal_error.NewNotFoundError("Auction not found")
+	}
+}
+
+func TestTrackAuctionAddsActiveAuction(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "1m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := setupInMemoryRepository()
+	auction := &auction_entity.Auction{
+		Id:        "active-auction",
+		Status:    auction_entity.Active,
+		Timestamp: time.Now(),
+	}
+	stubFindAuctionById(repo, auction)
+
+	if err := repo.TrackAuction(context.Background(), auction.Id); err != nil {
+		t.Fatalf("Expected auction to be tracked, got error: %v", err)
+	}
+
+	repo.activeAuctionsMutex.RLock()
+	endTime, exists := repo.activeAuctions[auction.Id]
+	repo.activeAuctionsMutex.RUnlock()
+
+	if !exists {
+		t.Fatalf("Expected auction to be present in active auctions map")
+	}
+
+	if expected := auction.Timestamp.Add(time.Minute); !endTime.Equal(expected) {
+		t.Errorf("Expected end time %v, got %v", expected, endTime)
+	}
+}
+
+func TestTrackAuctionRejections(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "1m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	completed := &auction_entity.Auction{
+		Id:        "completed-auction",
+		Status:    auction_entity.Completed,
+		Timestamp: time.Now(),
+	}
+	expired := &auction_entity.Auction{
+		Id:        "expired-auction",
+		Status:    auction_entity.Active,
+		Timestamp: time.Now().Add(-2 * time.Minute),
+	}
+
+	testCases := []struct {
+		name    string
+		id      string
+		errKind string
+	}{
+		{"completed auction", completed.Id, "bad_request"},
+		{"expired auction", expired.Id, "bad_request"},
+		{"missing auction", "missing-auction", "not_found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := setupInMemoryRepository()
+			stubFindAuctionById(repo, completed, expired)
+
+			err := repo.TrackAuction(context.Background(), tc.id)
+			if err == nil {
+				t.Fatalf("Expected an error when tracking %s", tc.id)
+			}
+
+			if err.Err != tc.errKind {
+				t.Errorf("Expected error kind %s, got %s", tc.errKind, err.Err)
+			}
+
+			if _, exists := repo.activeAuctions[tc.id]; exists {
+				t.Errorf("Expected auction %s not to be tracked", tc.id)
+			}
+		})
+	}
+}
+
+func TestNextClosingReturnsSoonestAuction(t *testing.T) {
+	repo := setupInMemoryRepository()
+	now := time.Now()
+
+	repo.activeAuctions["later"] = now.Add(10 * time.Minute)
+	repo.activeAuctions["soonest"] = now.Add(1 * time.Minute)
+	repo.activeAuctions["middle"] = now.Add(5 * time.Minute)
+
+	id, endTime, ok := repo.NextClosing()
+	if !ok {
+		t.Fatalf("Expected a tracked auction to be returned")
+	}
+
+	if id != "soonest" {
+		t.Errorf("Expected soonest auction to be returned, got %s", id)
+	}
+
+	if !endTime.Equal(now.Add(1 * time.Minute)) {
+		t.Errorf("Expected end time %v, got %v", now.Add(1*time.Minute), endTime)
+	}
+}
+
+func TestNextClosingWithoutTrackedAuctions(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	if _, _, ok := repo.NextClosing(); ok {
+		t.Errorf("Expected ok to be false when no auctions are tracked")
+	}
+}
+
+func TestActiveAuctionCount(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		return nil
+	}
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+			repo.CreateAuction(context.Background(), auction)
+			repo.ActiveAuctionCount()
+		}()
+	}
+	wg.Wait()
+
+	if count := repo.ActiveAuctionCount(); count != 5 {
+		t.Fatalf("Expected 5 tracked auctions after creation, got %d", count)
+	}
+
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+	if count := repo.ActiveAuctionCount(); count != 6 {
+		t.Fatalf("Expected 6 tracked auctions, got %d", count)
+	}
+
+	repo.checkExpiredAuctions()
+	if count := repo.ActiveAuctionCount(); count != 5 {
+		t.Errorf("Expected the expired auction to be removed from the count, got %d", count)
+	}
+}
+
+func TestGetAuctionEndTimePrefersTrackedValue(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.findAuctionById = func(ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+		t.Fatalf("Expected tracked auction not to hit the database")
+		return nil, nil
+	}
+
+	// Um término diferente do calculado, como após uma prorrogação
+	extended := time.Now().Add(42 * time.Minute)
+	repo.trackAuction("auction", extended, "books")
+
+	endTime, err := repo.GetAuctionEndTime(context.Background(), "auction")
+	if err != nil || !endTime.Equal(extended) {
+		t.Errorf("Expected tracked end time %v, got %v (%v)", extended, endTime, err)
+	}
+}
+
+func TestGetAuctionEndTimeFallsBackToStoredAuction(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "10m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := setupInMemoryRepository()
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stubFindAuctionById(repo, &auction_entity.Auction{
+		Id: "completed", Status: auction_entity.Completed, Timestamp: created})
+
+	endTime, err := repo.GetAuctionEndTime(context.Background(), "completed")
+	if err != nil || !endTime.Equal(created.Add(10*time.Minute)) {
+		t.Errorf("Expected end time computed from the timestamp, got %v (%v)", endTime, err)
+	}
+
+	if _, err := repo.GetAuctionEndTime(context.Background(), "missing"); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
+
+func TestEndTimeFilter(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	expected := bson.M{"$or": bson.A{
+		bson.M{"end_time": bson.M{"$lt": at.Unix()}},
+		bson.M{
+			"end_time":  bson.M{"$exists": false},
+			"timestamp": bson.M{"$lt": at.Add(-time.Hour).Unix()},
+		},
+	}}
+
+	if filter := endTimeFilter("$lt", at, time.Hour); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("Expected filter %v, got %v", expected, filter)
+	}
+}
+
+func TestStoredEndTimeSurvivesIntervalChange(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "10m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	repo := setupInMemoryRepository()
+	var stored *AuctionEntityMongo
+	repo.insertAuction = func(ctx context.Context, auction *AuctionEntityMongo) error {
+		stored = auction
+		return nil
+	}
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	auction.Timestamp = auction.Timestamp.Truncate(time.Second)
+	if _, err := repo.CreateAuction(context.Background(), auction); err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	expected := auction.Timestamp.Add(10 * time.Minute)
+	if stored.EndTime != expected.Unix() {
+		t.Fatalf("Expected end_time %d to be stored, got %d", expected.Unix(), stored.EndTime)
+	}
+
+	// Um reinício com outro AUCTION_INTERVAL não muda o prazo gravado
+	os.Setenv("AUCTION_INTERVAL", "1h")
+	if endTime := stored.ToEntity().EndTime; !endTime.Equal(expected) {
+		t.Errorf("Expected stored end time %v, got %v", expected, endTime)
+	}
+}
+
+func TestEndTimeFallsBackForLegacyDocuments(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "10m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	legacy := AuctionEntityMongo{Id: "legacy", Status: auction_entity.Active, Timestamp: created.Unix()}
+
+	if endTime := legacy.ToEntity().EndTime; !endTime.Equal(created.Add(10 * time.Minute)) {
+		t.Errorf("Expected end time computed from the timestamp, got %v", endTime)
+	}
+
+	draft := AuctionEntityMongo{Id: "draft", Status: auction_entity.Draft, Timestamp: created.Unix()}
+	if endTime := draft.ToEntity().EndTime; !endTime.IsZero() {
+		t.Errorf("Expected drafts to have no end time, got %v", endTime)
+	}
+}
+
+func TestLegacyEndTimeUsesRepositoryDuration(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "10m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	legacy := AuctionEntityMongo{Id: "legacy", Status: auction_entity.Active, Timestamp: created.Unix()}
+
+	repo := NewAuctionRepository(setupDisconnectedDatabase(t, "legacy_duration_test"),
+		WithoutMonitor(), WithDuration(time.Hour))
+	defer repo.Close()
+
+	// WithDuration vale mais que AUCTION_INTERVAL ao ler o documento
+	if endTime := legacy.toEntity(repo.AuctionDuration()).EndTime; !endTime.Equal(created.Add(time.Hour)) {
+		t.Errorf("Expected end time computed with the repository duration, got %v", endTime)
+	}
+}
+
+func TestTrackAuctionTwiceWarnsAndKeepsOriginalEndTime(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	defer logger.Replace(zap.New(core))()
+
+	repo := setupInMemoryRepository()
+	original := time.Now().Add(time.Minute)
+	repo.trackAuction("duplicated", original, "books")
+	repo.trackAuction("duplicated", original.Add(time.Hour), "books")
+
+	entries := logs.FilterMessage("Auction is already tracked, keeping its original end time").All()
+	if len(entries) != 1 || entries[0].ContextMap()["auction_id"] != "duplicated" {
+		t.Fatalf("Expected a warning for the repeated registration, got %v", logs.All())
+	}
+
+	repo.activeAuctionsMutex.RLock()
+	endTime := repo.activeAuctions["duplicated"]
+	repo.activeAuctionsMutex.RUnlock()
+	if !endTime.Equal(original) {
+		t.Errorf("Expected the original end time %v to be kept, got %v", original, endTime)
+	}
+
+	if active := repo.categoryGauge.Value("books", "active"); active != 1 {
+		t.Errorf("Expected the auction to be counted once, got %v", active)
+	}
+}
@@ -0,0 +1,135 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// Aplica uma mudança de status no banco e, se ela der certo, a alteração
+// correspondente no mapa de leilões em andamento, como em transitionWith.
+// Se o status no banco já mudou por outra operação (ex.: outra instância
+// cancelou o leilão), nada é alterado e applied é false
+func (ar *AuctionRepository) transition(
+	ctx context.Context,
+	id string,
+	to auction_entity.AuctionStatus,
+	precondition func() bool,
+	mutateTracking func()) (applied bool, err *internal_error.InternalError) {
+	return ar.transitionWith(ctx, id, precondition,
+		func(ctx context.Context) (bool, *internal_error.InternalError) {
+			if err := ar.updateAuctionStatus(ctx, id, to); err != nil {
+				if err.Code == "auction.status_changed" {
+					return false, nil
+				}
+				return false, err
+			}
+			return true, nil
+		},
+		mutateTracking)
+}
+
+// Aplica uma mudança de estado de um leilão em três passos: sob o lock de
+// escrita do mapa avalia precondition (opcional) e reserva o leilão; grava
+// a mudança com write (opcional) fora do lock; e, sob o lock de novo, aplica
+// mutateTracking se a gravação deu certo e libera a reserva. Enquanto a
+// reserva existe outras transições do mesmo leilão falham com o código
+// auction.transition_in_progress e o monitor não o fecha, então duas
+// mudanças nunca se sobrepõem. Aplicada a mudança, OnAuctionChanged é
+// avisado. Se precondition retornar false ou write não
+// aplicar a mudança, o mapa fica como estava e applied é false.
+// precondition e mutateTracking rodam com o lock já adquirido e não devem
+// chamar métodos que o adquiram de novo (ex.: trackAuction)
+func (ar *AuctionRepository) transitionWith(
+	ctx context.Context,
+	id string,
+	precondition func() bool,
+	write func(ctx context.Context) (bool, *internal_error.InternalError),
+	mutateTracking func()) (applied bool, err *internal_error.InternalError) {
+	if ctx.Err() != nil {
+		return false, internal_error.NewInternalServerError("Auction status transition cancelled")
+	}
+
+	ar.activeAuctionsMutex.Lock()
+	if ar.isReservedLocked(id) {
+		ar.activeAuctionsMutex.Unlock()
+		return false, errTransitionInProgress(id)
+	}
+	if precondition != nil && !precondition() {
+		ar.activeAuctionsMutex.Unlock()
+		return false, nil
+	}
+	ar.transitioning[id] = struct{}{}
+	ar.activeAuctionsMutex.Unlock()
+
+	// A reserva é liberada mesmo se a gravação entrar em pânico
+	defer func() {
+		ar.activeAuctionsMutex.Lock()
+		delete(ar.transitioning, id)
+		if applied && mutateTracking != nil {
+			mutateTracking()
+		}
+		ar.activeAuctionsMutex.Unlock()
+
+		if applied {
+			ar.notifyAuctionsChanged(id)
+		}
+	}()
+
+	if write == nil {
+		return true, nil
+	}
+	return write(ctx)
+}
+
+// Status a partir dos quais o leilão pode passar para to: só leilões ativos
+// são concluídos; os demais status exigem um leilão ainda não encerrado
+func statusesUpdatableTo(to auction_entity.AuctionStatus) []auction_entity.AuctionStatus {
+	if to == auction_entity.Completed {
+		return []auction_entity.AuctionStatus{auction_entity.Active}
+	}
+
+	return []auction_entity.AuctionStatus{
+		auction_entity.Active, auction_entity.Draft, auction_entity.Paused, auction_entity.Scheduled}
+}
+
+// Erro devolvido pela atualização de status quando o leilão não está mais
+// num status que permite a mudança
+func errStatusChanged(id string) *internal_error.InternalError {
+	return internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s status changed before the update", id)).WithCode("auction.status_changed")
+}
+
+// Erro devolvido quando outra mudança do mesmo leilão ainda está em curso
+func errTransitionInProgress(id string) *internal_error.InternalError {
+	return internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s is being changed by another operation", id)).WithCode("auction.transition_in_progress")
+}
+
+// Informa se o leilão está reservado por uma transição em curso; deve ser
+// chamada com activeAuctionsMutex adquirido
+func (ar *AuctionRepository) isReservedLocked(id string) bool {
+	_, reserved := ar.transitioning[id]
+	return reserved
+}
+
+// Informa se o leilão está no mapa; deve ser chamada com
+// activeAuctionsMutex adquirido
+func (ar *AuctionRepository) isTrackedLocked(id string) bool {
+	_, tracked := ar.activeAuctions[id]
+	return tracked
+}
+
+// Remove o leilão do mapa; deve ser chamada com activeAuctionsMutex
+// adquirido. Retorna a categoria e se o leilão ainda estava no mapa
+func (ar *AuctionRepository) untrackLocked(id string) (category string, wasTracked bool) {
+	if _, wasTracked = ar.activeAuctions[id]; !wasTracked {
+		return "", false
+	}
+
+	category = ar.activeCategories[id]
+	delete(ar.activeAuctions, id)
+	delete(ar.activeCategories, id)
+	return category, true
+}
@@ -0,0 +1,182 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrentTransitionsNeverExposeIntermediateState(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	var dbMutex sync.Mutex
+	dbStatus := make(map[string]auction_entity.AuctionStatus)
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		dbMutex.Lock()
+		dbStatus[id] = status
+		dbMutex.Unlock()
+		return nil
+	}
+
+	var closedEvents int32
+	repo.OnAuctionClosed = func(event AuctionClosedEvent) {
+		atomic.AddInt32(&closedEvents, 1)
+	}
+	stubFindAuctionById(repo)
+
+	const total = 50
+	for i := 0; i < total; i++ {
+		repo.trackAuction(fmt.Sprintf("auction-%d", i), time.Now().Add(-time.Second), "books")
+	}
+
+	// Observador: com o lock de leitura, um leilão fechado no banco só pode
+	// continuar no mapa enquanto a transição que o fechou estiver reservada
+	done := make(chan struct{})
+	var inconsistencies int32
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			repo.activeAuctionsMutex.RLock()
+			dbMutex.Lock()
+			for id, status := range dbStatus {
+				if _, tracked := repo.activeAuctions[id]; tracked && status == auction_entity.Completed &&
+					!repo.isReservedLocked(id) {
+					atomic.AddInt32(&inconsistencies, 1)
+				}
+			}
+			dbMutex.Unlock()
+			repo.activeAuctionsMutex.RUnlock()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo.processExpiredAuctions(context.Background(), 0)
+		}()
+	}
+	wg.Wait()
+	close(done)
+
+	if inconsistencies > 0 {
+		t.Errorf("Observer saw %d closed auctions still tracked", inconsistencies)
+	}
+
+	if closedEvents != total {
+		t.Errorf("Expected each auction to be closed once (%d events), got %d", total, closedEvents)
+	}
+
+	if got := repo.categoryGauge.Value("books", "completed"); got != total {
+		t.Errorf("Expected %d completed auctions in the gauge, got %v", total, got)
+	}
+}
+
+func TestTransitionLeavesTrackingUntouchedOnFailure(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.trackAuction("auction", time.Now().Add(time.Minute), "books")
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		return internal_error.NewInternalServerError("Error updating auction status")
+	}
+
+	mutated := false
+	applied, err := repo.transition(context.Background(), "auction", auction_entity.Completed,
+		nil, func() { mutated = true })
+
+	if err == nil || applied || mutated {
+		t.Fatalf("Expected failed update to skip the tracking mutation")
+	}
+
+	if _, tracked := repo.activeAuctions["auction"]; !tracked {
+		t.Errorf("Expected auction to remain tracked")
+	}
+}
+
+func TestTransitionWritesOutsideTheLock(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.trackAuction("auction", time.Now().Add(time.Minute), "books")
+	repo.trackAuction("expired", time.Now().Add(-time.Second), "books")
+
+	written := make(chan struct{})
+	release := make(chan struct{})
+	repo.updateAuctionStatus = func(ctx context.Context, id string, status auction_entity.AuctionStatus) *internal_error.InternalError {
+		if id == "auction" {
+			close(written)
+			<-release
+		}
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		repo.transition(context.Background(), "auction", auction_entity.Cancelled,
+			nil, func() { repo.untrackLocked("auction") })
+	}()
+	<-written
+
+	// Com a gravação em curso, o mapa continua acessível e o monitor fecha
+	// outros leilões normalmente
+	repo.checkExpiredAuctions()
+	if repo.isTracked("expired") {
+		t.Errorf("Expected the monitor to close other auctions during the write")
+	}
+
+	// O leilão reservado não muda por outra transição nem é fechado
+	_, err := repo.transition(context.Background(), "auction", auction_entity.Paused, nil, nil)
+	if err == nil || err.Code != "auction.transition_in_progress" {
+		t.Errorf("Expected a concurrent transition to be rejected, got %v", err)
+	}
+	if !repo.isTracked("auction") {
+		t.Errorf("Expected the auction to stay tracked until the write finishes")
+	}
+
+	close(release)
+	<-done
+
+	if repo.isTracked("auction") {
+		t.Errorf("Expected the tracking mutation to be applied after the write")
+	}
+	repo.activeAuctionsMutex.RLock()
+	reserved := repo.isReservedLocked("auction")
+	repo.activeAuctionsMutex.RUnlock()
+	if reserved {
+		t.Errorf("Expected the reservation to be released")
+	}
+}
+
+func TestMonitorSkipsReservedAuctions(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.trackAuction("auction", time.Now().Add(-time.Second), "books")
+
+	repo.activeAuctionsMutex.Lock()
+	repo.transitioning["auction"] = struct{}{}
+	repo.activeAuctionsMutex.Unlock()
+
+	repo.checkExpiredAuctions()
+
+	if !repo.isTracked("auction") {
+		t.Fatalf("Expected the reserved auction to stay tracked")
+	}
+
+	repo.activeAuctionsMutex.Lock()
+	delete(repo.transitioning, "auction")
+	repo.activeAuctionsMutex.Unlock()
+
+	repo.checkExpiredAuctions()
+
+	if repo.isTracked("auction") {
+		t.Errorf("Expected the auction to be closed once the reservation is released")
+	}
+}
@@ -0,0 +1,113 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// UpdateAuction corrige nome, categoria, descrição ou condição do produto
+// enquanto o leilão está ativo e ainda não recebeu lances. Os campos são
+// validados novamente como na criação. O filtro da escrita exige o leilão
+// ativo e sem has_bids, para que um lance aceito depois da contagem impeça
+// a edição
+func (ar *AuctionRepository) UpdateAuction(
+	ctx context.Context, id string, fields auction_entity.AuctionUpdate) (*auction_entity.Auction, *internal_error.InternalError) {
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	notActive := internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s is not active", id)).WithCode("auction.not_active")
+
+	if auctionEntity.Status != auction_entity.Active {
+		return nil, notActive
+	}
+
+	hasBids := internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s already has bids and can no longer be edited", id)).
+		WithCode("auction.has_bids")
+
+	total, _, countErr := ar.countAuctionBids(ctx, id, ar.auctionEndTime(auctionEntity))
+	if countErr != nil {
+		ar.logger.Error("Error trying to count auction bids", countErr, zap.String("auction_id", id))
+		return nil, internal_error.NewInternalServerError("Error trying to count auction bids")
+	}
+	if total > 0 {
+		return nil, hasBids
+	}
+
+	previousCategory := auctionEntity.Category
+	if err := auctionEntity.ApplyUpdate(fields); err != nil {
+		return nil, err
+	}
+	if err := auctionEntity.ValidateDescriptionWords(ar.minDescriptionWords); err != nil {
+		return nil, err
+	}
+	auctionEntity.ApplyCategoryCase(ar.categoryCase)
+
+	update := bson.M{"$set": bson.M{
+		"product_name":     auctionEntity.ProductName,
+		"category":         auctionEntity.Category,
+		"category_display": auctionEntity.CategoryDisplay,
+		"description":      auctionEntity.Description,
+		"condition":        auctionEntity.Condition,
+	}}
+	// O gauge por categoria acompanha a nova categoria do leilão, alterada
+	// no mapa na mesma transição que grava os campos
+	var tracked bool
+	applied, err := ar.transitionWith(ctx, id, nil,
+		func(ctx context.Context) (bool, *internal_error.InternalError) {
+			filter := bson.M{"_id": id, "status": auction_entity.Active, "has_bids": bson.M{"$ne": true}}
+			matched, updateErr := ar.updateAuction(ctx, filter, update)
+			if updateErr != nil {
+				ar.logger.Error("Error trying to update auction", updateErr, zap.String("auction_id", id))
+				return false, internal_error.NewInternalServerError("Error trying to update auction")
+			}
+			return matched > 0, nil
+		},
+		func() {
+			if _, tracked = ar.activeCategories[id]; tracked {
+				ar.activeCategories[id] = auctionEntity.Category
+			}
+		})
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		// Ainda ativo, o leilão só ficou de fora do filtro por ter recebido
+		// um lance depois da contagem
+		if current, findErr := ar.findAuctionById(ctx, id); findErr == nil && current.Status == auction_entity.Active {
+			return nil, hasBids
+		}
+		return nil, notActive
+	}
+
+	if tracked && auctionEntity.Category != previousCategory {
+		ar.recordCategoryMetric(previousCategory, auction_entity.Active, -1)
+		ar.recordCategoryMetric(auctionEntity.Category, auction_entity.Active, 1)
+	}
+
+	return auctionEntity, nil
+}
+
+// MarkAuctionHasBids marca que o leilão recebeu lances; deve ser chamada
+// antes de inserir o lance, para que UpdateAuction não edite um leilão cujo
+// lance já foi aceito
+func (ar *AuctionRepository) MarkAuctionHasBids(ctx context.Context, id string) *internal_error.InternalError {
+	ctx, cancel := context.WithTimeout(ctx, ar.opTimeout)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"has_bids": true}}
+	if _, err := ar.updateAuction(ctx, bson.M{"_id": id}, update); err != nil {
+		ar.logger.Error("Error trying to mark the auction as having bids", err, zap.String("auction_id", id))
+		return internal_error.NewInternalServerError("Error trying to mark the auction bids")
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func newEditableAuction(status auction_entity.AuctionStatus) *auction_entity.Auction {
+	return &auction_entity.Auction{
+		Id:          "auction",
+		SellerId:    "seller",
+		ProductName: "Phnoe",
+		Category:    "electronics",
+		Description: "A phone with a typo in its name",
+		Condition:   auction_entity.New,
+		Status:      status,
+		Timestamp:   time.Now(),
+	}
+}
+
+func TestUpdateAuctionBeforeFirstBid(t *testing.T) {
+	repo := setupInMemoryRepository()
+	auction := newEditableAuction(auction_entity.Active)
+	stubFindAuctionById(repo, auction)
+	stubCountAuctionBids(repo, 0, 0, nil)
+	repo.trackAuction(auction.Id, time.Now().Add(time.Hour), auction.Category)
+
+	var updates []bson.M
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		updates = append(updates, update)
+		return 1, nil
+	}
+
+	productName, category, condition := "Phone", "Mobile Phones", auction_entity.Used
+	updated, err := repo.UpdateAuction(context.Background(), auction.Id, auction_entity.AuctionUpdate{
+		ProductName: &productName, Category: &category, Condition: &condition})
+	if err != nil {
+		t.Fatalf("Expected auction to be updated, got %v", err)
+	}
+
+	if updated.ProductName != "Phone" || updated.Category != "mobile phones" || updated.Condition != auction_entity.Used {
+		t.Errorf("Expected updated fields, got %+v", updated)
+	}
+
+	if updated.Description != "A phone with a typo in its name" {
+		t.Errorf("Expected omitted fields to be kept, got %q", updated.Description)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("Expected a single update, got %d", len(updates))
+	}
+
+	if got := repo.categoryGauge.Value("mobile phones", "active"); got != 1 {
+		t.Errorf("Expected the active gauge to follow the new category, got %v", got)
+	}
+}
+
+func TestUpdateAuctionRejectsBidAfterCount(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo, newEditableAuction(auction_entity.Active))
+	stubCountAuctionBids(repo, 0, 0, nil)
+
+	// Um lance marcou o leilão entre a contagem e a escrita
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		if _, guarded := filter["has_bids"]; !guarded || filter["status"] != auction_entity.Active {
+			t.Errorf("Expected the update to be guarded by status and has_bids, got %v", filter)
+		}
+		return 0, nil
+	}
+
+	productName := "Phone"
+	_, err := repo.UpdateAuction(context.Background(), "auction", auction_entity.AuctionUpdate{ProductName: &productName})
+	if err == nil || err.Code != "auction.has_bids" {
+		t.Errorf("Expected auction.has_bids, got %v", err)
+	}
+}
+
+func TestMarkAuctionHasBids(t *testing.T) {
+	repo := setupInMemoryRepository()
+
+	var updates []bson.M
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		updates = append(updates, update)
+		return 1, nil
+	}
+
+	if err := repo.MarkAuctionHasBids(context.Background(), "auction"); err != nil {
+		t.Fatalf("Expected the auction to be marked, got %v", err)
+	}
+	if len(updates) != 1 || updates[0]["$set"].(bson.M)["has_bids"] != true {
+		t.Errorf("Expected has_bids to be set, got %v", updates)
+	}
+
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		return 0, errors.New("connection refused")
+	}
+	if err := repo.MarkAuctionHasBids(context.Background(), "auction"); err == nil {
+		t.Errorf("Expected an error when the mark cannot be written")
+	}
+}
+
+func TestUpdateAuctionRejected(t *testing.T) {
+	productName, blank := "Phone", ""
+
+	testCases := []struct {
+		name   string
+		status auction_entity.AuctionStatus
+		bids   int64
+		fields auction_entity.AuctionUpdate
+		code   string
+	}{
+		{"with bids", auction_entity.Active, 1, auction_entity.AuctionUpdate{ProductName: &productName}, "auction.has_bids"},
+		{"completed", auction_entity.Completed, 0, auction_entity.AuctionUpdate{ProductName: &productName}, "auction.not_active"},
+		{"cancelled", auction_entity.Cancelled, 0, auction_entity.AuctionUpdate{ProductName: &productName}, "auction.not_active"},
+		{"invalid field", auction_entity.Active, 0, auction_entity.AuctionUpdate{ProductName: &blank}, "product_name.too_short"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := setupInMemoryRepository()
+			auction := newEditableAuction(tc.status)
+			stubFindAuctionById(repo, auction)
+			stubCountAuctionBids(repo, tc.bids, tc.bids, nil)
+
+			updated := false
+			repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+				updated = true
+				return 1, nil
+			}
+
+			_, err := repo.UpdateAuction(context.Background(), auction.Id, tc.fields)
+			if err == nil || err.Code != tc.code {
+				t.Errorf("Expected error code %s, got %v", tc.code, err)
+			}
+
+			if !errors.Is(err, internal_error.ErrBadRequest) {
+				t.Errorf("Expected a bad request error, got %v", err)
+			}
+
+			if updated {
+				t.Errorf("Expected no update to reach the database")
+			}
+
+			if auction.ProductName != "Phnoe" {
+				t.Errorf("Expected the auction to be left unchanged, got %q", auction.ProductName)
+			}
+		})
+	}
+}
+
+func TestUpdateAuctionNotFound(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo)
+
+	_, err := repo.UpdateAuction(context.Background(), "missing", auction_entity.AuctionUpdate{})
+	if !errors.Is(err, internal_error.ErrNotFound) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	if errors.Is(err, internal_error.ErrBadRequest) {
+		t.Errorf("Expected not found not to match bad request")
+	}
+}
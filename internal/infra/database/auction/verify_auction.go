@@ -0,0 +1,94 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Resultado da verificação de consistência de um leilão; Issues vazio
+// indica que status, monitoramento e lances estão de acordo
+type AuctionIntegrityReport struct {
+	AuctionId string                       `json:"auction_id"`
+	Status    auction_entity.AuctionStatus `json:"status"`
+	Tracked   bool                         `json:"tracked"`
+	EndTime   time.Time                    `json:"end_time"`
+	Issues    []string                     `json:"issues"`
+}
+
+func (r *AuctionIntegrityReport) Consistent() bool {
+	return len(r.Issues) == 0
+}
+
+// VerifyAuction compara o status persistido com o mapa de leilões em
+// andamento e com os lances registrados
+func (ar *AuctionRepository) VerifyAuction(
+	ctx context.Context, id string) (*AuctionIntegrityReport, *internal_error.InternalError) {
+	auctionEntity, err := ar.findAuctionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ar.activeAuctionsMutex.RLock()
+	_, tracked := ar.activeAuctions[id]
+	ar.activeAuctionsMutex.RUnlock()
+
+	report := &AuctionIntegrityReport{
+		AuctionId: id,
+		Status:    auctionEntity.Status,
+		Tracked:   tracked,
+		EndTime:   ar.auctionEndTime(auctionEntity),
+		Issues:    []string{},
+	}
+
+	switch auctionEntity.Status {
+	case auction_entity.Active:
+		if !tracked {
+			report.Issues = append(report.Issues, "active auction is not tracked by the monitor")
+		}
+		if ar.now().After(report.EndTime) {
+			report.Issues = append(report.Issues, "active auction is past its end time")
+		}
+	case auction_entity.Completed:
+		if tracked {
+			report.Issues = append(report.Issues, "completed auction is still tracked by the monitor")
+		}
+
+		total, beforeEnd, countErr := ar.countAuctionBids(ctx, id, report.EndTime)
+		if countErr != nil {
+			return nil, internal_error.NewInternalServerError("Error trying to count auction bids")
+		}
+		if total > 0 && beforeEnd == 0 {
+			report.Issues = append(report.Issues, "completed auction has bids but none placed before its end time, so there is no winner")
+		}
+	case auction_entity.Draft:
+		if tracked {
+			report.Issues = append(report.Issues, "draft auction is tracked by the monitor")
+		}
+	case auction_entity.Scheduled:
+		if tracked {
+			report.Issues = append(report.Issues, "scheduled auction is tracked by the monitor before its start time")
+		}
+	}
+
+	return report, nil
+}
+
+func (ar *AuctionRepository) countAuctionBidsImpl(
+	ctx context.Context, auctionId string, endTime time.Time) (int64, int64, error) {
+	bids := ar.Collection.Database().Collection("bids")
+
+	total, err := bids.CountDocuments(ctx, bson.M{"auction_id": auctionId})
+	if err != nil || total == 0 {
+		return total, 0, err
+	}
+
+	beforeEnd, err := bids.CountDocuments(ctx, bson.M{
+		"auction_id": auctionId,
+		"timestamp":  bson.M{"$lte": endTime.Unix()},
+	})
+	return total, beforeEnd, err
+}
@@ -0,0 +1,78 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+)
+
+func stubCountAuctionBids(repo *AuctionRepository, total, beforeEnd int64, err error) {
+	repo.countAuctionBids = func(ctx context.Context, auctionId string, endTime time.Time) (int64, int64, error) {
+		return total, beforeEnd, err
+	}
+}
+
+func TestVerifyAuction(t *testing.T) {
+	os.Setenv("AUCTION_INTERVAL", "1m")
+	defer os.Unsetenv("AUCTION_INTERVAL")
+
+	now := time.Now()
+	testCases := []struct {
+		name      string
+		auction   *auction_entity.Auction
+		tracked   bool
+		total     int64
+		beforeEnd int64
+		issues    int
+	}{
+		{"consistent active", &auction_entity.Auction{Id: "a", Status: auction_entity.Active, Timestamp: now}, true, 0, 0, 0},
+		{"untracked active", &auction_entity.Auction{Id: "a", Status: auction_entity.Active, Timestamp: now}, false, 0, 0, 1},
+		{"active past end time", &auction_entity.Auction{Id: "a", Status: auction_entity.Active, Timestamp: now.Add(-time.Hour)}, true, 0, 0, 1},
+		{"consistent completed", &auction_entity.Auction{Id: "a", Status: auction_entity.Completed, Timestamp: now.Add(-time.Hour)}, false, 3, 3, 0},
+		{"completed without bids", &auction_entity.Auction{Id: "a", Status: auction_entity.Completed, Timestamp: now.Add(-time.Hour)}, false, 0, 0, 0},
+		{"completed still tracked", &auction_entity.Auction{Id: "a", Status: auction_entity.Completed, Timestamp: now.Add(-time.Hour)}, true, 1, 1, 1},
+		{"completed without winner", &auction_entity.Auction{Id: "a", Status: auction_entity.Completed, Timestamp: now.Add(-time.Hour)}, false, 2, 0, 1},
+		{"tracked draft", &auction_entity.Auction{Id: "a", Status: auction_entity.Draft, Timestamp: now}, true, 0, 0, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := setupInMemoryRepository()
+			stubFindAuctionById(repo, tc.auction)
+			stubCountAuctionBids(repo, tc.total, tc.beforeEnd, nil)
+			if tc.tracked {
+				repo.activeAuctions[tc.auction.Id] = repo.auctionEndTime(tc.auction)
+			}
+
+			report, err := repo.VerifyAuction(context.Background(), tc.auction.Id)
+			if err != nil {
+				t.Fatalf("Expected a report, got %v", err)
+			}
+
+			if len(report.Issues) != tc.issues {
+				t.Errorf("Expected %d issues, got %v", tc.issues, report.Issues)
+			}
+
+			if report.Consistent() != (tc.issues == 0) {
+				t.Errorf("Expected Consistent() to be %v", tc.issues == 0)
+			}
+		})
+	}
+}
+
+func TestVerifyAuctionErrors(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo, &auction_entity.Auction{Id: "done", Status: auction_entity.Completed})
+	stubCountAuctionBids(repo, 0, 0, errors.New("connection refused"))
+
+	if _, err := repo.VerifyAuction(context.Background(), "missing"); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+
+	if _, err := repo.VerifyAuction(context.Background(), "done"); err == nil || err.Err != "internal_server_error" {
+		t.Errorf("Expected internal_server_error when bids cannot be counted, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// FindAuctionByIdAndCountView busca o leilão e incrementa o contador de
+// visualizações na mesma operação (findOneAndUpdate)
+func (ar *AuctionRepository) FindAuctionByIdAndCountView(
+	ctx context.Context, id, viewerUserId string) (*auction_entity.Auction, *internal_error.InternalError) {
+	if !ar.shouldCountView(id, viewerUserId, ar.now()) {
+		return ar.FindAuctionById(ctx, id)
+	}
+
+	filter := bson.M{"_id": id}
+	update := bson.M{"$inc": bson.M{"views": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var auctionEntityMongo AuctionEntityMongo
+	if err := ar.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&auctionEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			ar.logger.Error("Auction not found", err, zap.String("auction_id", id))
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Auction not found with this id = %s", id))
+		}
+
+		ar.logger.Error("Error trying to count auction view", err, zap.String("auction_id", id))
+		return nil, internal_error.NewInternalServerError("Error trying to count auction view")
+	}
+
+	if err := validateEnums(
+		auctionEntityMongo.Id, auctionEntityMongo.Status, auctionEntityMongo.Condition); err != nil {
+		return nil, err
+	}
+
+	return auctionEntityMongo.toEntity(ar.AuctionDuration()), nil
+}
+
+// Indica se a visualização deve ser contada; visualizações repetidas do
+// mesmo usuário dentro da janela configurada são ignoradas
+func (ar *AuctionRepository) shouldCountView(id, viewerUserId string, now time.Time) bool {
+	if ar.viewDedupWindow <= 0 || viewerUserId == "" {
+		return true
+	}
+
+	key := id + ":" + viewerUserId
+
+	ar.recentViewsMutex.Lock()
+	defer ar.recentViewsMutex.Unlock()
+
+	if lastView, ok := ar.recentViews[key]; ok && now.Sub(lastView) < ar.viewDedupWindow {
+		return false
+	}
+
+	// Remove registros antigos para o mapa não crescer indefinidamente
+	for viewKey, lastView := range ar.recentViews {
+		if now.Sub(lastView) >= ar.viewDedupWindow {
+			delete(ar.recentViews, viewKey)
+		}
+	}
+
+	ar.recentViews[key] = now
+	return true
+}
+
+// Lê a janela de deduplicação de AUCTION_VIEW_DEDUP_WINDOW (desativada por padrão)
+func getViewDedupWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv("AUCTION_VIEW_DEDUP_WINDOW"))
+	if err != nil || window < 0 {
+		return 0
+	}
+
+	return window
+}
@@ -0,0 +1,67 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+func TestShouldCountViewWithoutDedupWindow(t *testing.T) {
+	repo := setupInMemoryRepository()
+	now := time.Now()
+
+	if !repo.shouldCountView("auction", "user", now) || !repo.shouldCountView("auction", "user", now) {
+		t.Errorf("Expected every view to be counted when dedup window is disabled")
+	}
+}
+
+func TestShouldCountViewDeduplicatesWithinWindow(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.viewDedupWindow = time.Minute
+	now := time.Now()
+
+	if !repo.shouldCountView("auction", "user", now) {
+		t.Fatalf("Expected first view to be counted")
+	}
+
+	if repo.shouldCountView("auction", "user", now.Add(30*time.Second)) {
+		t.Errorf("Expected repeated view within the window to be ignored")
+	}
+
+	if !repo.shouldCountView("auction", "other-user", now.Add(30*time.Second)) {
+		t.Errorf("Expected view from another user to be counted")
+	}
+
+	if !repo.shouldCountView("auction", "user", now.Add(2*time.Minute)) {
+		t.Errorf("Expected view after the window to be counted")
+	}
+}
+
+func TestFindAuctionByIdAndCountView(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	seed := AuctionEntityMongo{Id: "viewed-auction", Condition: auction_entity.New, ProductName: "Phone", Timestamp: time.Now().Unix()}
+	if _, err := repo.Collection.InsertOne(ctx, seed); err != nil {
+		t.Fatalf("Failed to seed auction: %v", err)
+	}
+
+	for expectedViews := int64(1); expectedViews <= 2; expectedViews++ {
+		auction, err := repo.FindAuctionByIdAndCountView(ctx, seed.Id, "")
+		if err != nil {
+			t.Fatalf("Failed to find auction: %v", err)
+		}
+
+		if auction.ProductName != seed.ProductName {
+			t.Errorf("Expected product name %s, got %s", seed.ProductName, auction.ProductName)
+		}
+
+		if auction.Views != expectedViews {
+			t.Errorf("Expected %d views, got %d", expectedViews, auction.Views)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// WinningBidFinder escolhe o maior lance de um leilão comparando os valores
+// na moeda base, como BidRepository.FindWinningBidByAuctionId (retorna
+// NotFound quando não há lances comparáveis), e converte o valor de um
+// lance para a moeda base, em que estão o preço de reserva e o preço final
+type WinningBidFinder interface {
+	FindWinningBidByAuctionId(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError)
+	AmountInBaseCurrency(bid bid_entity.Bid) (amount float64, ok bool)
+}
+
+// UseWinningBidFinder passa a escolher o vencedor dos leilões encerrados
+// com finder; sem ele os lances são ordenados pelo valor bruto. Pode ser
+// chamada com o monitor em andamento
+func (ar *AuctionRepository) UseWinningBidFinder(finder WinningBidFinder) {
+	ar.winningBidFinderMutex.Lock()
+	defer ar.winningBidFinderMutex.Unlock()
+	ar.winningBidFinder = finder
+}
+
+// Valor do lance na moeda base, convertido pelo WinningBidFinder; sem ele
+// o valor bruto é usado
+func (ar *AuctionRepository) amountInBaseCurrency(bid bid_entity.Bid) (float64, bool) {
+	ar.winningBidFinderMutex.RLock()
+	finder := ar.winningBidFinder
+	ar.winningBidFinderMutex.RUnlock()
+
+	if finder == nil {
+		return bid.Amount, true
+	}
+	return finder.AmountInBaseCurrency(bid)
+}
+
+// Maior lance de um leilão encerrado
+type auctionWinner struct {
+	BidId  string  `bson:"_id"`
+	UserId string  `bson:"user_id"`
+	Amount float64 `bson:"amount"`
+}
+
+// Grava no leilão encerrado o maior lance e seu autor; sem lances os campos
+// ficam vazios, e se o maior lance não atingir o preço de reserva o leilão
+// é marcado com reserve_not_met. Falhas são apenas registradas para não
+// travar o monitor
+func (ar *AuctionRepository) recordWinner(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), ar.opTimeout)
+	defer cancel()
+
+	winner, err := ar.findHighestBid(ctx, id)
+	if err != nil {
+		ar.logger.Error("Error trying to find the winning bid", err, zap.String("auction_id", id))
+		return
+	}
+	if winner == nil {
+		return
+	}
+
+	auctionEntity, findErr := ar.findAuctionById(ctx, id)
+	if findErr != nil {
+		ar.logger.Error("Error trying to load auction to record its winner", findErr,
+			zap.String("auction_id", id))
+		return
+	}
+
+	update := bson.M{"$set": bson.M{
+		"winner_bid_id":  winner.BidId,
+		"winner_user_id": winner.UserId,
+		"final_price":    winner.Amount,
+	}}
+	reserveNotMet := winner.Amount < auctionEntity.ReservePrice
+	if reserveNotMet {
+		update = bson.M{"$set": bson.M{"reserve_not_met": true}}
+	}
+
+	if _, err := ar.updateAuction(ctx, bson.M{"_id": id}, update); err != nil {
+		ar.logger.Error("Error trying to record the auction winner", err, zap.String("auction_id", id))
+		return
+	}
+
+	if reserveNotMet {
+		ar.logger.Info("Auction closed without a winner: highest bid is below the reserve price",
+			zap.String("auction_id", id),
+			zap.Float64("highest_bid", winner.Amount))
+		return
+	}
+
+	ar.logger.Info("Auction won",
+		zap.String("auction_id", id),
+		zap.String("user_id", winner.UserId),
+		zap.String("bid_id", winner.BidId))
+}
+
+// Maior lance do leilão, com desempate pelo lance mais antigo. A escolha é
+// delegada ao WinningBidFinder configurado, que compara lances em moedas
+// diferentes
+func (ar *AuctionRepository) findHighestBidImpl(ctx context.Context, auctionId string) (*auctionWinner, error) {
+	ar.winningBidFinderMutex.RLock()
+	finder := ar.winningBidFinder
+	ar.winningBidFinderMutex.RUnlock()
+
+	if finder != nil {
+		bid, err := finder.FindWinningBidByAuctionId(ctx, auctionId)
+		if err != nil {
+			if errors.Is(err, internal_error.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		// O valor vai para a moeda base antes de ser comparado à reserva
+		amount, ok := finder.AmountInBaseCurrency(*bid)
+		if !ok {
+			return nil, fmt.Errorf("winning bid %s of auction %s has no rate to the base currency", bid.Id, auctionId)
+		}
+		return &auctionWinner{BidId: bid.Id, UserId: bid.UserId, Amount: amount}, nil
+	}
+
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}}).
+		SetProjection(bson.M{"_id": 1, "user_id": 1, "amount": 1})
+
+	var winner auctionWinner
+	err := ar.Collection.Database().Collection("bids").
+		FindOne(ctx, bson.M{"auction_id": auctionId}, opts).Decode(&winner)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &winner, nil
+}
@@ -0,0 +1,227 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAutoCloseRecordsWinner(t *testing.T) {
+	repo := setupInMemoryRepository()
+	stubFindAuctionById(repo, &auction_entity.Auction{Id: "sold", Status: auction_entity.Completed})
+	repo.findHighestBid = func(ctx context.Context, auctionId string) (*auctionWinner, error) {
+		if auctionId == "sold" {
+			return &auctionWinner{BidId: "bid", UserId: "user", Amount: 300}, nil
+		}
+		return nil, nil
+	}
+
+	updates := map[string]bson.M{}
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		updates[filter["_id"].(string)] = update
+		return 1, nil
+	}
+
+	repo.trackAuction("sold", time.Now().Add(-time.Second), "books")
+	repo.trackAuction("unsold", time.Now().Add(-time.Second), "books")
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	expected := bson.M{"$set": bson.M{"winner_bid_id": "bid", "winner_user_id": "user", "final_price": 300.0}}
+	if got := updates["sold"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected winner update %v, got %v", expected, got)
+	}
+
+	if _, updated := updates["unsold"]; updated {
+		t.Errorf("Expected no winner update for an auction without bids")
+	}
+}
+
+func TestRecordWinnerHonorsReservePrice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		topBid   float64
+		expected bson.M
+	}{
+		{"top bid below reserve", 799, bson.M{"$set": bson.M{"reserve_not_met": true}}},
+		{"top bid meets reserve", 800, bson.M{"$set": bson.M{
+			"winner_bid_id": "bid", "winner_user_id": "user", "final_price": 800.0}}},
+		{"top bid above reserve", 950, bson.M{"$set": bson.M{
+			"winner_bid_id": "bid", "winner_user_id": "user", "final_price": 950.0}}},
+	}
+
+	for _, tc := range testCases {
+		repo := setupInMemoryRepository()
+		stubFindAuctionById(repo, &auction_entity.Auction{
+			Id: "auction", Status: auction_entity.Completed, ReservePrice: 800})
+		repo.findHighestBid = func(ctx context.Context, auctionId string) (*auctionWinner, error) {
+			return &auctionWinner{BidId: "bid", UserId: "user", Amount: tc.topBid}, nil
+		}
+
+		var got bson.M
+		repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+			got = update
+			return 1, nil
+		}
+
+		repo.recordWinner("auction")
+
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: expected update %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestRecordWinnerIgnoresLookupErrors(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.findHighestBid = func(ctx context.Context, auctionId string) (*auctionWinner, error) {
+		return nil, errors.New("connection refused")
+	}
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		t.Fatalf("Expected no update when the winner lookup fails")
+		return 0, nil
+	}
+
+	repo.recordWinner("auction")
+}
+
+func TestAutoCloseStoresHighestBidAsWinner(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "electronics", "A valid description", auction_entity.New)
+	if _, err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	now := time.Now().Unix()
+	bids := []interface{}{
+		bson.M{"_id": "low", "auction_id": auction.Id, "user_id": "alice", "amount": 100.0, "timestamp": now},
+		bson.M{"_id": "high", "auction_id": auction.Id, "user_id": "bob", "amount": 300.0, "timestamp": now},
+		bson.M{"_id": "tied-later", "auction_id": auction.Id, "user_id": "carol", "amount": 300.0, "timestamp": now + 1},
+		bson.M{"_id": "other", "auction_id": "other-auction", "user_id": "dave", "amount": 900.0, "timestamp": now},
+	}
+	if _, err := database.Collection("bids").InsertMany(ctx, bids); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	repo.clock = ClockFunc(func() time.Time { return repo.auctionEndTime(auction).Add(time.Second) })
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	closed, err := repo.FindAuctionById(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Expected auction to be found, got %v", err)
+	}
+
+	if closed.Status != auction_entity.Completed || closed.WinnerBidId != "high" ||
+		closed.WinnerUserId != "bob" || closed.FinalPrice != 300 {
+		t.Errorf("Expected bid high by bob to win the completed auction, got %+v", closed)
+	}
+}
+
+func TestAutoCloseWithReserveNotMet(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := NewAuctionRepository(database)
+	defer repo.cancelFunc()
+
+	auction, _ := auction_entity.CreateAuction("seller", "Phone", "electronics", "A valid description", auction_entity.New)
+	auction.SetReservePrice(1000)
+	if _, err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Expected auction to be created, got %v", err)
+	}
+
+	bid := bson.M{"_id": "bid", "auction_id": auction.Id, "user_id": "bob", "amount": 900.0, "timestamp": time.Now().Unix()}
+	if _, err := database.Collection("bids").InsertOne(ctx, bid); err != nil {
+		t.Fatalf("Failed to seed bid: %v", err)
+	}
+
+	repo.clock = ClockFunc(func() time.Time { return repo.auctionEndTime(auction).Add(time.Second) })
+	repo.processExpiredAuctions(context.Background(), 0)
+
+	closed, err := repo.FindAuctionById(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Expected auction to be found, got %v", err)
+	}
+
+	if closed.Status != auction_entity.Completed || closed.WinnerBidId != "" || !closed.ReserveNotMet {
+		t.Errorf("Expected completed auction without winner and reserve not met, got %+v", closed)
+	}
+}
+
+// Maior lance fixo, como o escolhido pelo repositório de lances; lances em
+// USD valem 5 na moeda base
+type fakeWinningBidFinder struct {
+	bid *bid_entity.Bid
+}
+
+func (f fakeWinningBidFinder) AmountInBaseCurrency(bid bid_entity.Bid) (float64, bool) {
+	if bid.Currency == "USD" {
+		return bid.Amount * 5, true
+	}
+	return bid.Amount, bid.Currency == ""
+}
+
+func (f fakeWinningBidFinder) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	if f.bid == nil {
+		return nil, internal_error.NewNotFoundError("no bids")
+	}
+	return f.bid, nil
+}
+
+func TestFindHighestBidUsesWinningBidFinder(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.findHighestBid = repo.findHighestBidImpl
+
+	repo.UseWinningBidFinder(fakeWinningBidFinder{bid: &bid_entity.Bid{
+		Id: "usd-bid", UserId: "user", AuctionId: "auction", Amount: 100, Currency: "USD"}})
+
+	winner, err := repo.findHighestBid(context.Background(), "auction")
+	if err != nil {
+		t.Fatalf("Expected the winner to be found, got %v", err)
+	}
+	expected := &auctionWinner{BidId: "usd-bid", UserId: "user", Amount: 500}
+	if !reflect.DeepEqual(winner, expected) {
+		t.Errorf("Expected winner %+v, got %+v", expected, winner)
+	}
+
+	repo.UseWinningBidFinder(fakeWinningBidFinder{})
+	if winner, err := repo.findHighestBid(context.Background(), "auction"); err != nil || winner != nil {
+		t.Errorf("Expected no winner without comparable bids, got %+v (%v)", winner, err)
+	}
+}
+
+func TestRecordWinnerComparesReserveInBaseCurrency(t *testing.T) {
+	repo := setupInMemoryRepository()
+	repo.findHighestBid = repo.findHighestBidImpl
+	stubFindAuctionById(repo, &auction_entity.Auction{
+		Id: "auction", Status: auction_entity.Completed, ReservePrice: 400})
+
+	// 100 USD ficam abaixo da reserva pelo valor bruto, mas valem 500
+	repo.UseWinningBidFinder(fakeWinningBidFinder{bid: &bid_entity.Bid{
+		Id: "bid", UserId: "user", AuctionId: "auction", Amount: 100, Currency: "USD"}})
+
+	var got bson.M
+	repo.updateAuction = func(ctx context.Context, filter, update bson.M) (int64, error) {
+		got = update
+		return 1, nil
+	}
+
+	repo.recordWinner("auction")
+
+	expected := bson.M{"$set": bson.M{"winner_bid_id": "bid", "winner_user_id": "user", "final_price": 500.0}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected the reserve to be met in the base currency, got %v", got)
+	}
+}
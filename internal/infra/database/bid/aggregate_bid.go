@@ -0,0 +1,77 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AuctionBidVolume struct {
+	Auction     auction_entity.Auction
+	TotalAmount float64
+	BidCount    int64
+}
+
+type auctionBidVolumeMongo struct {
+	AuctionId   string                     `bson:"_id"`
+	TotalAmount float64                    `bson:"total_amount"`
+	BidCount    int64                      `bson:"bid_count"`
+	Auction     auction.AuctionEntityMongo `bson:"auction"`
+}
+
+// TopAuctionsByBidVolume retorna os leilões com maior volume de lances
+// (soma dos valores), junto com os dados de cada leilão
+func (bd *BidRepository) TopAuctionsByBidVolume(
+	ctx context.Context, limit int64) ([]AuctionBidVolume, *internal_error.InternalError) {
+	if limit <= 0 {
+		return nil, internal_error.NewBadRequestError("limit must be greater than zero")
+	}
+
+	cursor, err := bd.Collection.Aggregate(ctx, topAuctionsByBidVolumePipeline(limit))
+	if err != nil {
+		logger.Error("Error trying to aggregate bid volume by auction", err)
+		return nil, internal_error.NewInternalServerError("Error trying to aggregate bid volume by auction")
+	}
+	defer cursor.Close(ctx)
+
+	var volumesMongo []auctionBidVolumeMongo
+	if err := cursor.All(ctx, &volumesMongo); err != nil {
+		logger.Error("Error trying to decode bid volume by auction", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode bid volume by auction")
+	}
+
+	var volumes []AuctionBidVolume
+	for _, volume := range volumesMongo {
+		volumes = append(volumes, AuctionBidVolume{
+			Auction:     *volume.Auction.ToEntity(),
+			TotalAmount: volume.TotalAmount,
+			BidCount:    volume.BidCount,
+		})
+	}
+
+	return volumes, nil
+}
+
+func topAuctionsByBidVolumePipeline(limit int64) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":          "$auction_id",
+			"total_amount": bson.M{"$sum": "$amount"},
+			"bid_count":    bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "total_amount", Value: -1}, {Key: "bid_count", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "auctions",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "auction",
+		}}},
+		{{Key: "$unwind", Value: "$auction"}},
+	}
+}
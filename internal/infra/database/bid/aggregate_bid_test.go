@@ -0,0 +1,80 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"testing"
+	"time"
+)
+
+func TestTopAuctionsByBidVolumeRejectsInvalidLimit(t *testing.T) {
+	repo := &BidRepository{}
+
+	if _, err := repo.TopAuctionsByBidVolume(context.Background(), 0); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for a zero limit, got %v", err)
+	}
+}
+
+func TestTopAuctionsByBidVolumePipelineRespectsLimit(t *testing.T) {
+	pipeline := topAuctionsByBidVolumePipeline(3)
+
+	found := false
+	for _, stage := range pipeline {
+		if stage[0].Key == "$limit" {
+			found = true
+			if stage[0].Value != int64(3) {
+				t.Errorf("Expected $limit 3, got %v", stage[0].Value)
+			}
+		}
+	}
+
+	if !found {
+		t.Errorf("Expected pipeline to contain a $limit stage")
+	}
+}
+
+func TestTopAuctionsByBidVolume(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	auctionRepository := auction.NewAuctionRepository(database)
+	repo := NewBidRepository(database, auctionRepository)
+
+	auctions := []interface{}{
+		auction.AuctionEntityMongo{Id: "small", ProductName: "Pen"},
+		auction.AuctionEntityMongo{Id: "large", ProductName: "Car"},
+		auction.AuctionEntityMongo{Id: "medium", ProductName: "Phone"},
+	}
+	if _, err := auctionRepository.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	now := time.Now().Unix()
+	bids := []interface{}{
+		BidEntityMongo{Id: "1", AuctionId: "small", Amount: 10, Timestamp: now},
+		BidEntityMongo{Id: "2", AuctionId: "large", Amount: 500, Timestamp: now},
+		BidEntityMongo{Id: "3", AuctionId: "large", Amount: 700, Timestamp: now},
+		BidEntityMongo{Id: "4", AuctionId: "medium", Amount: 100, Timestamp: now},
+		BidEntityMongo{Id: "5", AuctionId: "medium", Amount: 150, Timestamp: now},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, bids); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	volumes, err := repo.TopAuctionsByBidVolume(ctx, 2)
+	if err != nil {
+		t.Fatalf("Failed to aggregate bid volume: %v", err)
+	}
+
+	if len(volumes) != 2 {
+		t.Fatalf("Expected 2 auctions, got %d", len(volumes))
+	}
+
+	if volumes[0].Auction.Id != "large" || volumes[0].TotalAmount != 1200 || volumes[0].BidCount != 2 {
+		t.Errorf("Expected large auction first with 1200 over 2 bids, got %+v", volumes[0])
+	}
+
+	if volumes[1].Auction.Id != "medium" || volumes[1].Auction.ProductName != "Phone" {
+		t.Errorf("Expected medium auction second, got %+v", volumes[1])
+	}
+}
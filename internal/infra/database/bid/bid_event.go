@@ -0,0 +1,73 @@
+package bid
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+)
+
+// BidAcceptedEvent é enviado às páginas de leilão em tempo real;
+// CurrentPrice está na moeda base
+type BidAcceptedEvent struct {
+	AuctionId    string  `json:"auction_id"`
+	BidderId     string  `json:"bidder_id"`
+	Amount       float64 `json:"amount"`
+	CurrentPrice float64 `json:"current_price"`
+}
+
+// Dispara onBidAccepted, se configurado, protegendo o fluxo de lances
+// contra pânicos do callback
+func (bd *BidRepository) publishBidAccepted(ctx context.Context, bid *BidEntityMongo) {
+	if bd.onBidAccepted == nil {
+		return
+	}
+
+	event := BidAcceptedEvent{
+		AuctionId:    bid.AuctionId,
+		BidderId:     bid.UserId,
+		Amount:       bid.Amount,
+		CurrentPrice: bid.BaseAmount,
+	}
+
+	if highest, err := bd.highestBid(ctx, bid.AuctionId); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to load current price for auction %s", bid.AuctionId), err)
+	} else if amount, ok := bd.AmountInBaseCurrency(*highest); ok && amount > event.CurrentPrice {
+		event.CurrentPrice = amount
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(fmt.Sprintf("Recovered from panic in onBidAccepted for auction %s", bid.AuctionId),
+				fmt.Errorf("%v", r))
+		}
+	}()
+
+	bd.onBidAccepted(event)
+}
+
+// WithOnBidAccepted define o callback chamado após cada lance aceito; como
+// os lances rodam em goroutines, o callback só é definido na criação
+func WithOnBidAccepted(hook func(event BidAcceptedEvent)) Option {
+	return func(bd *BidRepository) {
+		bd.onBidAccepted = hook
+	}
+}
+
+// Publisher entrega os eventos de lance a um tópico externo (ex.: fila que
+// alimenta as páginas de leilão em tempo real)
+type Publisher interface {
+	Publish(ctx context.Context, topic string, event BidAcceptedEvent) error
+}
+
+// PublishInBackground adapta um Publisher para WithOnBidAccepted; cada
+// evento é publicado em uma goroutine para não atrasar o lance
+func PublishInBackground(publisher Publisher, topic string) func(event BidAcceptedEvent) {
+	return func(event BidAcceptedEvent) {
+		go func() {
+			if err := publisher.Publish(context.Background(), topic, event); err != nil {
+				logger.Error(fmt.Sprintf("Error publishing bid accepted event for auction %s to topic %s",
+					event.AuctionId, topic), err)
+			}
+		}()
+	}
+}
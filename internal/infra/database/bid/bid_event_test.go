@@ -0,0 +1,134 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+	"time"
+)
+
+func TestOnBidAcceptedReceivesPayload(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository("auction")
+
+	var events []BidAcceptedEvent
+	WithOnBidAccepted(func(event BidAcceptedEvent) {
+		events = append(events, event)
+	})(repo)
+
+	first := []bid_entity.Bid{{Id: "1", UserId: "alice", AuctionId: "auction", Amount: 100, Timestamp: time.Now()}}
+	second := []bid_entity.Bid{{Id: "2", UserId: "bob", AuctionId: "auction", Amount: 150, Timestamp: time.Now()}}
+
+	repo.CreateBid(context.Background(), first)
+	repo.CreateBid(context.Background(), second)
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	expected := BidAcceptedEvent{AuctionId: "auction", BidderId: "bob", Amount: 150, CurrentPrice: 150}
+	if events[1] != expected {
+		t.Errorf("Expected event %+v, got %+v", expected, events[1])
+	}
+}
+
+func TestOnBidAcceptedPanicDoesNotBreakBidding(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	repo.onBidAccepted = func(event BidAcceptedEvent) {
+		panic("subscriber failure")
+	}
+
+	bids := []bid_entity.Bid{{Id: "1", UserId: "alice", AuctionId: "auction", Amount: 100, Timestamp: time.Now()}}
+	if err := repo.CreateBid(context.Background(), bids); err != nil {
+		t.Fatalf("Expected bid to be created, got %v", err)
+	}
+
+	if len(*inserted) != 1 {
+		t.Errorf("Expected the bid to be stored despite the panicking hook")
+	}
+}
+
+func TestOnBidAcceptedNotFiredForRejectedBid(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository("auction")
+	repo.maxBidsPerUser = 1
+
+	fired := 0
+	repo.onBidAccepted = func(event BidAcceptedEvent) { fired++ }
+
+	repo.CreateBid(context.Background(), []bid_entity.Bid{{Id: "1", UserId: "alice", AuctionId: "auction", Amount: 10}})
+	repo.CreateBid(context.Background(), []bid_entity.Bid{{Id: "2", UserId: "alice", AuctionId: "auction", Amount: 20}})
+
+	if fired != 1 {
+		t.Errorf("Expected the hook to fire only for the accepted bid, fired %d times", fired)
+	}
+}
+
+func TestOnBidAcceptedRunsWithoutAuctionLock(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository("auction")
+
+	locked := false
+	repo.onBidAccepted = func(event BidAcceptedEvent) {
+		locked = repo.auctionLocks.isLocked(event.AuctionId)
+	}
+
+	bids := []bid_entity.Bid{{Id: "1", UserId: "alice", AuctionId: "auction", Amount: 100, Timestamp: time.Now()}}
+	if err := repo.CreateBid(context.Background(), bids); err != nil {
+		t.Fatalf("Expected bid to be created, got %v", err)
+	}
+
+	if locked {
+		t.Errorf("Expected the hook to run after the auction lock is released")
+	}
+}
+
+func TestOnBidAcceptedCurrentPriceInBaseCurrency(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository("auction")
+	repo.exchangeRates = fakeExchangeRates{"USD->BRL": 5}
+
+	var events []BidAcceptedEvent
+	repo.onBidAccepted = func(event BidAcceptedEvent) {
+		events = append(events, event)
+	}
+
+	repo.CreateBid(context.Background(), []bid_entity.Bid{
+		{Id: "1", UserId: "alice", AuctionId: "auction", Amount: 100, Currency: "BRL", Timestamp: time.Now()}})
+	repo.CreateBid(context.Background(), []bid_entity.Bid{
+		{Id: "2", UserId: "bob", AuctionId: "auction", Amount: 30, Currency: "USD", Timestamp: time.Now()}})
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	expected := BidAcceptedEvent{AuctionId: "auction", BidderId: "bob", Amount: 30, CurrentPrice: 150}
+	if events[1] != expected {
+		t.Errorf("Expected event %+v, got %+v", expected, events[1])
+	}
+}
+
+type publisherFunc func(ctx context.Context, topic string, event BidAcceptedEvent) error
+
+func (f publisherFunc) Publish(ctx context.Context, topic string, event BidAcceptedEvent) error {
+	return f(ctx, topic, event)
+}
+
+func TestPublishInBackground(t *testing.T) {
+	type delivery struct {
+		topic string
+		event BidAcceptedEvent
+	}
+	delivered := make(chan delivery, 1)
+	hook := PublishInBackground(publisherFunc(func(ctx context.Context, topic string, event BidAcceptedEvent) error {
+		delivered <- delivery{topic: topic, event: event}
+		return nil
+	}), "bids.accepted")
+
+	hook(BidAcceptedEvent{AuctionId: "auction", BidderId: "alice", Amount: 100, CurrentPrice: 100})
+
+	select {
+	case got := <-delivered:
+		if got.topic != "bids.accepted" || got.event.AuctionId != "auction" {
+			t.Errorf("Expected event for auction on bids.accepted, got %+v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the publisher to receive the event")
+	}
+}
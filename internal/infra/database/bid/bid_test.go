@@ -0,0 +1,105 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Conecta ao MongoDB indicado em MONGODB_TEST_URL; o teste é pulado
+// quando a variável não está definida ou o banco está indisponível
+func setupMongoDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	mongoURL := os.Getenv("MONGODB_TEST_URL")
+	if mongoURL == "" {
+		t.Skip("Skipping test that requires MongoDB; set MONGODB_TEST_URL to run it")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURL))
+	if err != nil {
+		t.Skipf("Skipping test, MongoDB unavailable: %v", err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Skipf("Skipping test, MongoDB unavailable: %v", err)
+	}
+
+	database := client.Database("bid_test")
+	_ = database.Drop(ctx)
+
+	t.Cleanup(func() {
+		_ = database.Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	})
+
+	return database
+}
+
+// Repositório em memória: o leilão informado já está em cache como ativo
+// e as inserções ficam registradas em memória
+func setupInMemoryBidRepository(activeAuctionIds ...string) (*BidRepository, *[]BidEntityMongo) {
+	repo := &BidRepository{
+		auctionStatusMap:      make(map[string]auction_entity.AuctionStatus),
+		auctionEndTimeMap:     make(map[string]time.Time),
+		auctionStartingBidMap: make(map[string]float64),
+		auctionStatusMapMutex: &sync.Mutex{},
+		auctionEndTimeMutex:   &sync.Mutex{},
+		maxBidsPerUser:        100,
+		auctionLocks:          newAuctionLocks(),
+		baseCurrency:          "BRL",
+		clock:                 auction.ClockFunc(time.Now),
+	}
+
+	for _, auctionId := range activeAuctionIds {
+		repo.auctionStatusMap[auctionId] = auction_entity.Active
+		repo.auctionEndTimeMap[auctionId] = time.Now().Add(time.Hour)
+	}
+
+	inserted := &[]BidEntityMongo{}
+	repo.insertBid = func(ctx context.Context, bid *BidEntityMongo) error {
+		*inserted = append(*inserted, *bid)
+		return nil
+	}
+	repo.countUserBids = func(ctx context.Context, auctionId, userId string) (int64, error) {
+		var count int64
+		for _, bid := range *inserted {
+			if bid.AuctionId == auctionId && bid.UserId == userId {
+				count++
+			}
+		}
+		return count, nil
+	}
+
+	repo.findBids = func(ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
+		var bids []bid_entity.Bid
+		for _, bid := range *inserted {
+			if bid.AuctionId == auctionId {
+				bids = append(bids, *bid.ToEntity())
+			}
+		}
+		return bids, nil
+	}
+	repo.highestBid = func(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+		bids, _ := repo.findBids(ctx, auctionId)
+		highest := bid_entity.HighestBid(bids, repo.baseCurrency, repo.exchangeRates)
+		if highest == nil {
+			return nil, internal_error.NewNotFoundError("No bids found")
+		}
+		return highest, nil
+	}
+
+	return repo, inserted
+}
@@ -2,15 +2,20 @@ package bid
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/internal_error"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -19,35 +24,150 @@ type BidEntityMongo struct {
 	UserId    string  `bson:"user_id"`
 	AuctionId string  `bson:"auction_id"`
 	Amount    float64 `bson:"amount"`
+	Currency  string  `bson:"currency,omitempty"`
 	Timestamp int64   `bson:"timestamp"`
+
+	// Valor convertido para a moeda base na inserção; usado para ordenar
+	// lances de moedas diferentes
+	BaseAmount float64 `bson:"base_amount,omitempty"`
+}
+
+func (bm *BidEntityMongo) ToEntity() *bid_entity.Bid {
+	return &bid_entity.Bid{
+		Id:        bm.Id,
+		UserId:    bm.UserId,
+		AuctionId: bm.AuctionId,
+		Amount:    bm.Amount,
+		Currency:  bm.Currency,
+		Timestamp: time.Unix(bm.Timestamp, 0),
+	}
 }
 
 type BidRepository struct {
 	Collection            *mongo.Collection
 	AuctionRepository     *auction.AuctionRepository
-	auctionInterval       time.Duration
 	auctionStatusMap      map[string]auction_entity.AuctionStatus
 	auctionEndTimeMap     map[string]time.Time
+	auctionStartingBidMap map[string]float64
 	auctionStatusMapMutex *sync.Mutex
 	auctionEndTimeMutex   *sync.Mutex
+
+	// Limite de lances de um mesmo usuário em um mesmo leilão; a contagem
+	// e a inserção são serializadas por leilão
+	maxBidsPerUser int64
+	auctionLocks   *auctionLocks
+
+	// Quanto um lance precisa superar o maior lance atual (0 exige apenas
+	// um valor maior)
+	minBidIncrement float64
+
+	// Tolerância após o fim do leilão em que lances ainda são aceitos
+	gracePeriod time.Duration
+	// Relógio do repositório de leilões, para que lances e fechamento
+	// concordem sobre o fim do leilão
+	clock auction.Clock
+
+	// Moeda em que os lances são comparados e conversor opcional; sem
+	// conversor, apenas lances na moeda base disputam o maior lance
+	baseCurrency  string
+	exchangeRates bid_entity.ExchangeRateProvider
+
+	// Chamada após cada lance aceito (opcional)
+	onBidAccepted func(event BidAcceptedEvent)
+
+	// Funções de acesso ao banco - podem ser substituídas em testes
+	insertBid     func(ctx context.Context, bid *BidEntityMongo) error
+	countUserBids func(ctx context.Context, auctionId, userId string) (int64, error)
+	highestBid    func(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError)
+	findBids      func(ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError)
+}
+
+// Option configura o BidRepository na criação, antes de qualquer lance
+type Option func(*BidRepository)
+
+// WithExchangeRates define o conversor usado para comparar lances em outras
+// moedas com a moeda base
+func WithExchangeRates(rates bid_entity.ExchangeRateProvider) Option {
+	return func(bd *BidRepository) {
+		bd.exchangeRates = rates
+	}
 }
 
-func NewBidRepository(database *mongo.Database, auctionRepository *auction.AuctionRepository) *BidRepository {
-	return &BidRepository{
-		auctionInterval:       getAuctionInterval(),
+func NewBidRepository(database *mongo.Database, auctionRepository *auction.AuctionRepository, opts ...Option) *BidRepository {
+	repo := &BidRepository{
 		auctionStatusMap:      make(map[string]auction_entity.AuctionStatus),
 		auctionEndTimeMap:     make(map[string]time.Time),
+		auctionStartingBidMap: make(map[string]float64),
 		auctionStatusMapMutex: &sync.Mutex{},
 		auctionEndTimeMutex:   &sync.Mutex{},
+		maxBidsPerUser:        getMaxBidsPerUser(),
+		minBidIncrement:       getMinBidIncrement(),
+		gracePeriod:           auctionRepository.GracePeriod(),
+		clock:                 auctionRepository.Clock(),
+		auctionLocks:          newAuctionLocks(),
+		baseCurrency:          getBaseCurrency(),
 		Collection:            database.Collection("bids"),
 		AuctionRepository:     auctionRepository,
 	}
+
+	repo.insertBid = repo.insertBidImpl
+	repo.countUserBids = repo.countUserBidsImpl
+	repo.highestBid = repo.FindWinningBidByAuctionId
+	repo.findBids = func(ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
+		return repo.FindBidByAuctionId(ctx, auctionId, bid_entity.FindBidsOptions{})
+	}
+
+	for _, opt := range opts {
+		opt(repo)
+	}
+
+	// Status e término em cache são descartados a cada mudança do leilão
+	auctionRepository.OnAuctionChanged(repo.InvalidateAuction)
+	// O vencedor dos leilões encerrados é escolhido na moeda base
+	auctionRepository.UseWinningBidFinder(repo)
+
+	return repo
+}
+
+// AmountInBaseCurrency converte o valor do lance para a moeda base, em que
+// os lances são comparados
+func (bd *BidRepository) AmountInBaseCurrency(bid bid_entity.Bid) (float64, bool) {
+	return bid.AmountIn(bd.baseCurrency, bd.exchangeRates)
+}
+
+// InvalidateAuction descarta o status, o término e o lance inicial do
+// leilão guardados em cache; o próximo lance os lê de novo do banco
+func (bd *BidRepository) InvalidateAuction(auctionId string) {
+	bd.auctionStatusMapMutex.Lock()
+	delete(bd.auctionStatusMap, auctionId)
+	delete(bd.auctionStartingBidMap, auctionId)
+	bd.auctionStatusMapMutex.Unlock()
+
+	bd.auctionEndTimeMutex.Lock()
+	delete(bd.auctionEndTimeMap, auctionId)
+	bd.auctionEndTimeMutex.Unlock()
 }
 
 func (bd *BidRepository) CreateBid(
 	ctx context.Context,
 	bidEntities []bid_entity.Bid) *internal_error.InternalError {
 	var wg sync.WaitGroup
+
+	// Guarda a primeira recusa por valor para devolver ao chamador
+	var rejection *internal_error.InternalError
+	var rejectionMutex sync.Mutex
+	reject := func(err *internal_error.InternalError) {
+		if err == nil {
+			return
+		}
+
+		rejectionMutex.Lock()
+		if rejection == nil {
+			rejection = err
+		}
+		rejectionMutex.Unlock()
+	}
+
 	for _, bid := range bidEntities {
 		wg.Add(1)
 		go func(bidValue bid_entity.Bid) {
@@ -55,6 +175,7 @@ func (bd *BidRepository) CreateBid(
 
 			bd.auctionStatusMapMutex.Lock()
 			auctionStatus, okStatus := bd.auctionStatusMap[bidValue.AuctionId]
+			startingBid := bd.auctionStartingBidMap[bidValue.AuctionId]
 			bd.auctionStatusMapMutex.Unlock()
 
 			bd.auctionEndTimeMutex.Lock()
@@ -66,20 +187,17 @@ func (bd *BidRepository) CreateBid(
 				UserId:    bidValue.UserId,
 				AuctionId: bidValue.AuctionId,
 				Amount:    bidValue.Amount,
+				Currency:  bidValue.Currency,
 				Timestamp: bidValue.Timestamp.Unix(),
 			}
 
 			if okEndTime && okStatus {
-				now := time.Now()
-				if auctionStatus == auction_entity.Completed || now.After(auctionEndTime) {
-					return
-				}
-
-				if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-					logger.Error("Error trying to insert bid", err)
+				now := bd.clock.Now()
+				if auctionStatus != auction_entity.Active || now.After(auctionEndTime.Add(bd.gracePeriod)) {
 					return
 				}
 
+				reject(bd.insertBidWithinUserLimit(ctx, bidEntityMongo, startingBid))
 				return
 			}
 
@@ -88,34 +206,257 @@ func (bd *BidRepository) CreateBid(
 				logger.Error("Error trying to find auction by id", err)
 				return
 			}
-			if auctionEntity.Status == auction_entity.Completed {
+			if auctionEntity.Status != auction_entity.Active {
 				return
 			}
 
 			bd.auctionStatusMapMutex.Lock()
 			bd.auctionStatusMap[bidValue.AuctionId] = auctionEntity.Status
+			bd.auctionStartingBidMap[bidValue.AuctionId] = auctionEntity.StartingBid
 			bd.auctionStatusMapMutex.Unlock()
 
 			bd.auctionEndTimeMutex.Lock()
-			bd.auctionEndTimeMap[bidValue.AuctionId] = auctionEntity.Timestamp.Add(bd.auctionInterval)
+			bd.auctionEndTimeMap[bidValue.AuctionId] = auctionEntity.EndTime
 			bd.auctionEndTimeMutex.Unlock()
 
-			if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-				logger.Error("Error trying to insert bid", err)
-				return
-			}
+			reject(bd.insertBidWithinUserLimit(ctx, bidEntityMongo, auctionEntity.StartingBid))
 		}(bid)
 	}
 	wg.Wait()
-	return nil
+	return rejection
+}
+
+// Insere o lance apenas se o usuário ainda não atingiu o limite de lances
+// no leilão; a contagem e a inserção são serializadas por leilão para que
+// lances do mesmo lote não ultrapassem o limite. Retorna BadRequestError
+// quando o usuário atingiu o limite ou o lance fica abaixo do lance inicial
+// ou não supera o maior lance atual. O evento do lance aceito é publicado
+// depois de liberar o lock, para que um callback lento não segure os demais
+// lances
+func (bd *BidRepository) insertBidWithinUserLimit(
+	ctx context.Context, bidEntityMongo *BidEntityMongo, startingBid float64) *internal_error.InternalError {
+	inserted, rejection := bd.insertBidLocked(ctx, bidEntityMongo, startingBid)
+	if inserted {
+		bd.publishBidAccepted(ctx, bidEntityMongo)
+	}
+	return rejection
+}
+
+// Conta, confere e insere o lance com o lock do leilão; retorna se o lance
+// foi inserido
+func (bd *BidRepository) insertBidLocked(
+	ctx context.Context, bidEntityMongo *BidEntityMongo, startingBid float64) (bool, *internal_error.InternalError) {
+	unlock := bd.auctionLocks.Lock(bidEntityMongo.AuctionId)
+	defer unlock()
+
+	count, err := bd.countUserBids(ctx, bidEntityMongo.AuctionId, bidEntityMongo.UserId)
+	if err != nil {
+		logger.Error("Error trying to count user bids", err)
+		return false, nil
+	}
+
+	if count >= bd.maxBidsPerUser {
+		logger.Info(fmt.Sprintf("Bid rejected: user %s reached the limit of %d bids on auction %s",
+			bidEntityMongo.UserId, bd.maxBidsPerUser, bidEntityMongo.AuctionId))
+		return false, internal_error.NewBadRequestError(
+			fmt.Sprintf("User reached the limit of %d bids on this auction", bd.maxBidsPerUser)).
+			WithCode("bid.user_limit_reached")
+	}
+
+	if rejection := bd.checkBidAmount(ctx, bidEntityMongo, startingBid); rejection != nil {
+		return false, rejection
+	}
+
+	// A marca é gravada antes do lance, para que uma edição concorrente do
+	// leilão (UpdateAuction) não passe pelo filtro depois de o lance existir
+	if bd.AuctionRepository != nil {
+		if err := bd.AuctionRepository.MarkAuctionHasBids(ctx, bidEntityMongo.AuctionId); err != nil {
+			return false, nil
+		}
+	}
+
+	if err := bd.insertBid(ctx, bidEntityMongo); err != nil {
+		logger.Error("Error trying to insert bid", err)
+		return false, nil
+	}
+
+	// Um lance que atinge a compra imediata encerra o leilão na hora e tem
+	// precedência sobre a prorrogação. Lances na janela final prorrogam o
+	// leilão; a prorrogação invalida o cache de término (OnAuctionChanged),
+	// para que os próximos lances não sejam recusados
+	if bd.AuctionRepository != nil {
+		if !bd.AuctionRepository.CloseIfInstant(ctx, bidEntityMongo.AuctionId,
+			bidEntityMongo.UserId, bidEntityMongo.Id, bidEntityMongo.BaseAmount) {
+			bd.AuctionRepository.ExtendIfClosing(ctx, bidEntityMongo.AuctionId)
+		}
+	}
+
+	return true, nil
 }
 
-func getAuctionInterval() time.Duration {
-	auctionInterval := os.Getenv("AUCTION_INTERVAL")
-	duration, err := time.ParseDuration(auctionInterval)
+// CheckBidAmount verifica o valor do lance (lance inicial e maior lance
+// atual) antes de ele entrar no lote, para que a recusa chegue a quem deu o
+// lance; a verificação é repetida na
+// inserção, quando outro lance pode ter superado este
+func (bd *BidRepository) CheckBidAmount(ctx context.Context, bid bid_entity.Bid) *internal_error.InternalError {
+	amount, ok := bid.AmountIn(bd.baseCurrency, bd.exchangeRates)
+	if !ok {
+		return bd.unsupportedCurrency(bid.AuctionId, bid.Currency)
+	}
+
+	startingBid, err := bd.auctionStartingBid(ctx, bid.AuctionId)
+	if err != nil {
+		return err
+	}
+	if rejection := checkStartingBid(bid.AuctionId, amount, startingBid); rejection != nil {
+		return rejection
+	}
+
+	return bd.checkHighestBid(ctx, bid.AuctionId, amount)
+}
+
+// Lance inicial do leilão, lido do cache ou do banco; o leilão ativo lido do
+// banco entra no cache como em CreateBid
+func (bd *BidRepository) auctionStartingBid(
+	ctx context.Context, auctionId string) (float64, *internal_error.InternalError) {
+	bd.auctionStatusMapMutex.Lock()
+	_, cached := bd.auctionStatusMap[auctionId]
+	startingBid := bd.auctionStartingBidMap[auctionId]
+	bd.auctionStatusMapMutex.Unlock()
+
+	if cached || bd.AuctionRepository == nil {
+		return startingBid, nil
+	}
+
+	auctionEntity, err := bd.AuctionRepository.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return 0, err
+	}
+	if auctionEntity.Status != auction_entity.Active {
+		return auctionEntity.StartingBid, nil
+	}
+
+	bd.auctionStatusMapMutex.Lock()
+	bd.auctionStatusMap[auctionId] = auctionEntity.Status
+	bd.auctionStartingBidMap[auctionId] = auctionEntity.StartingBid
+	bd.auctionStatusMapMutex.Unlock()
+
+	bd.auctionEndTimeMutex.Lock()
+	bd.auctionEndTimeMap[auctionId] = auctionEntity.EndTime
+	bd.auctionEndTimeMutex.Unlock()
+
+	return auctionEntity.StartingBid, nil
+}
+
+// Verifica se o lance atinge o lance inicial e supera o maior lance atual
+// pelo incremento mínimo. A comparação é feita na moeda base, cujo valor fica
+// gravado no lance; lances que não podem ser convertidos são recusados
+func (bd *BidRepository) checkBidAmount(
+	ctx context.Context, bidEntityMongo *BidEntityMongo, startingBid float64) *internal_error.InternalError {
+	amount, ok := bidEntityMongo.ToEntity().AmountIn(bd.baseCurrency, bd.exchangeRates)
+	if !ok {
+		return bd.unsupportedCurrency(bidEntityMongo.AuctionId, bidEntityMongo.Currency)
+	}
+	bidEntityMongo.BaseAmount = amount
+
+	if rejection := checkStartingBid(bidEntityMongo.AuctionId, amount, startingBid); rejection != nil {
+		return rejection
+	}
+
+	return bd.checkHighestBid(ctx, bidEntityMongo.AuctionId, amount)
+}
+
+// Recusa lances em moedas sem conversão para a moeda base, que não poderiam
+// ser comparados com os demais
+func (bd *BidRepository) unsupportedCurrency(auctionId, currency string) *internal_error.InternalError {
+	logger.Info(fmt.Sprintf("Bid rejected: currency %s cannot be converted to %s on auction %s",
+		currency, bd.baseCurrency, auctionId))
+	return internal_error.NewBadRequestError(
+		fmt.Sprintf("Bids in %s are not accepted; use %s", currency, bd.baseCurrency)).
+		WithCode("bid.unsupported_currency")
+}
+
+// Verifica se amount, na moeda base, atinge o lance inicial do leilão
+func checkStartingBid(auctionId string, amount, startingBid float64) *internal_error.InternalError {
+	if amount >= startingBid {
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Bid rejected: amount %.2f is below the starting bid %.2f on auction %s",
+		amount, startingBid, auctionId))
+	return internal_error.NewBadRequestError(
+		fmt.Sprintf("Bid must be at least the starting bid of %.2f", startingBid)).
+		WithCode("bid.below_starting_bid")
+}
+
+// Verifica se amount, na moeda base, supera o maior lance atual do leilão
+// pelo incremento mínimo
+func (bd *BidRepository) checkHighestBid(
+	ctx context.Context, auctionId string, amount float64) *internal_error.InternalError {
+	highest, err := bd.highestBid(ctx, auctionId)
 	if err != nil {
-		return time.Minute * 5
+		if errors.Is(err, internal_error.ErrNotFound) {
+			return nil
+		}
+
+		logger.Error("Error trying to find the highest bid", err)
+		return err
+	}
+
+	highestAmount, ok := highest.AmountIn(bd.baseCurrency, bd.exchangeRates)
+	if !ok {
+		return nil
+	}
+
+	if amount <= highestAmount || amount < highestAmount+bd.minBidIncrement {
+		logger.Info(fmt.Sprintf("Bid rejected: amount %.2f does not exceed the highest bid %.2f on auction %s",
+			amount, highestAmount, auctionId))
+		message := fmt.Sprintf("Bid must be greater than the current highest bid of %.2f", highestAmount)
+		if bd.minBidIncrement > 0 {
+			message = fmt.Sprintf("Bid must be at least %.2f", highestAmount+bd.minBidIncrement)
+		}
+
+		return internal_error.NewBadRequestError(message).WithCode("bid.too_low")
+	}
+
+	return nil
+}
+
+func (bd *BidRepository) insertBidImpl(ctx context.Context, bid *BidEntityMongo) error {
+	_, err := bd.Collection.InsertOne(ctx, bid)
+	return err
+}
+
+func (bd *BidRepository) countUserBidsImpl(ctx context.Context, auctionId, userId string) (int64, error) {
+	return bd.Collection.CountDocuments(ctx, bson.M{"auction_id": auctionId, "user_id": userId})
+}
+
+// Lê o limite de lances por usuário em um leilão de MAX_BIDS_PER_USER_PER_AUCTION
+func getMaxBidsPerUser() int64 {
+	value, err := strconv.ParseInt(os.Getenv("MAX_BIDS_PER_USER_PER_AUCTION"), 10, 64)
+	if err != nil || value <= 0 {
+		return 100
+	}
+
+	return value
+}
+
+// Lê o incremento mínimo entre lances de BID_MIN_INCREMENT (padrão 0)
+func getMinBidIncrement() float64 {
+	value, err := strconv.ParseFloat(os.Getenv("BID_MIN_INCREMENT"), 64)
+	if err != nil || value < 0 {
+		return 0
+	}
+
+	return value
+}
+
+// Moeda base para comparar lances (BID_BASE_CURRENCY, padrão BRL)
+func getBaseCurrency() string {
+	currency := strings.ToUpper(strings.TrimSpace(os.Getenv("BID_BASE_CURRENCY")))
+	if currency == "" {
+		return "BRL"
 	}
 
-	return duration
+	return currency
 }
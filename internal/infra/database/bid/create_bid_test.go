@@ -0,0 +1,321 @@
+package bid
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Lances do mesmo lote são inseridos em ordem arbitrária; os testes de limite
+// por usuário desconsideram o maior lance atual
+func ignoreHighestBid(repo *BidRepository) {
+	repo.highestBid = func(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+		return nil, internal_error.NewNotFoundError("no bids")
+	}
+}
+
+func TestCreateBidRejectsBidsOverUserLimit(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	repo.maxBidsPerUser = 2
+	ignoreHighestBid(repo)
+
+	var bids []bid_entity.Bid
+	for i := 0; i < 3; i++ {
+		bids = append(bids, bid_entity.Bid{
+			Id: fmt.Sprintf("bid-%d", i), UserId: "user", AuctionId: "auction",
+			Amount: float64(10 * (i + 1)), Timestamp: time.Now(),
+		})
+	}
+
+	if err := repo.CreateBid(context.Background(), bids[:2]); err != nil {
+		t.Fatalf("Failed to create bids: %v", err)
+	}
+
+	if len(*inserted) != 2 {
+		t.Fatalf("Expected bids up to the limit to be inserted, got %d", len(*inserted))
+	}
+
+	if err := repo.CreateBid(context.Background(), bids[2:]); err == nil || err.Code != "bid.user_limit_reached" {
+		t.Errorf("Expected bid.user_limit_reached, got %v", err)
+	}
+
+	if len(*inserted) != 2 {
+		t.Errorf("Expected bid over the limit to be rejected, got %d bids", len(*inserted))
+	}
+}
+
+func TestCreateBidUserLimitWithinSameBatch(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	repo.maxBidsPerUser = 1
+	ignoreHighestBid(repo)
+
+	bids := []bid_entity.Bid{
+		{Id: "1", UserId: "user", AuctionId: "auction", Amount: 10, Timestamp: time.Now()},
+		{Id: "2", UserId: "user", AuctionId: "auction", Amount: 20, Timestamp: time.Now()},
+		{Id: "3", UserId: "other", AuctionId: "auction", Amount: 30, Timestamp: time.Now()},
+	}
+
+	if err := repo.CreateBid(context.Background(), bids); err == nil || err.Code != "bid.user_limit_reached" {
+		t.Errorf("Expected bid.user_limit_reached for the second bid of the same user, got %v", err)
+	}
+
+	if len(*inserted) != 2 {
+		t.Errorf("Expected one bid per user to be inserted, got %d", len(*inserted))
+	}
+}
+
+func TestGetMaxBidsPerUser(t *testing.T) {
+	os.Setenv("MAX_BIDS_PER_USER_PER_AUCTION", "7")
+	if value := getMaxBidsPerUser(); value != 7 {
+		t.Errorf("Expected limit 7, got %d", value)
+	}
+
+	os.Setenv("MAX_BIDS_PER_USER_PER_AUCTION", "invalid")
+	if value := getMaxBidsPerUser(); value != 100 {
+		t.Errorf("Expected default limit 100, got %d", value)
+	}
+
+	os.Unsetenv("MAX_BIDS_PER_USER_PER_AUCTION")
+}
+
+func TestCreateBidIgnoresBidsOnClosedAuctions(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("active")
+	repo.auctionStatusMap["completed"] = auction_entity.Completed
+	repo.auctionEndTimeMap["completed"] = time.Now().Add(time.Hour)
+	repo.auctionStatusMap["expired"] = auction_entity.Active
+	repo.auctionEndTimeMap["expired"] = time.Now().Add(-time.Second)
+
+	bids := []bid_entity.Bid{
+		{Id: "1", UserId: "user", AuctionId: "active", Amount: 10, Timestamp: time.Now()},
+		{Id: "2", UserId: "user", AuctionId: "completed", Amount: 10, Timestamp: time.Now()},
+		{Id: "3", UserId: "user", AuctionId: "expired", Amount: 10, Timestamp: time.Now()},
+	}
+
+	if err := repo.CreateBid(context.Background(), bids); err != nil {
+		t.Fatalf("Failed to create bids: %v", err)
+	}
+
+	if len(*inserted) != 1 || (*inserted)[0].AuctionId != "active" {
+		t.Errorf("Expected only the bid on the active auction to be inserted, got %+v", *inserted)
+	}
+}
+
+func TestCreateBidAcceptsLateBidsWithinGracePeriod(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository()
+	repo.gracePeriod = 5 * time.Second
+	repo.auctionStatusMap["within-grace"] = auction_entity.Active
+	repo.auctionEndTimeMap["within-grace"] = time.Now().Add(-time.Second)
+	repo.auctionStatusMap["past-grace"] = auction_entity.Active
+	repo.auctionEndTimeMap["past-grace"] = time.Now().Add(-10 * time.Second)
+
+	bids := []bid_entity.Bid{
+		{Id: "1", UserId: "user", AuctionId: "within-grace", Amount: 10, Timestamp: time.Now()},
+		{Id: "2", UserId: "user", AuctionId: "past-grace", Amount: 10, Timestamp: time.Now()},
+	}
+
+	if err := repo.CreateBid(context.Background(), bids); err != nil {
+		t.Fatalf("Failed to create bids: %v", err)
+	}
+
+	if len(*inserted) != 1 || (*inserted)[0].AuctionId != "within-grace" {
+		t.Errorf("Expected only the bid within the grace period to be inserted, got %+v", *inserted)
+	}
+}
+
+func TestCreateBidUsesRepositoryClock(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("running")
+	// Segundo o relógio do repositório o leilão já terminou
+	repo.clock = auction.ClockFunc(func() time.Time { return time.Now().Add(2 * time.Hour) })
+
+	bids := []bid_entity.Bid{{Id: "1", UserId: "user", AuctionId: "running", Amount: 10, Timestamp: time.Now()}}
+	if err := repo.CreateBid(context.Background(), bids); err != nil {
+		t.Fatalf("Failed to create bids: %v", err)
+	}
+
+	if len(*inserted) != 0 {
+		t.Errorf("Expected the bid to be ignored after the end by the repository clock, got %+v", *inserted)
+	}
+}
+
+func TestCreateBidRejectsBidEqualToHighest(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	ctx := context.Background()
+
+	repo.CreateBid(ctx, []bid_entity.Bid{{Id: "1", UserId: "alice", AuctionId: "auction", Amount: 100, Timestamp: time.Now()}})
+
+	err := repo.CreateBid(ctx, []bid_entity.Bid{{Id: "2", UserId: "bob", AuctionId: "auction", Amount: 100, Timestamp: time.Now()}})
+	if err == nil || err.Err != "bad_request" || err.Code != "bid.too_low" {
+		t.Fatalf("Expected a bad request for an equal bid, got %v", err)
+	}
+
+	if len(*inserted) != 1 {
+		t.Errorf("Expected the equal bid not to be inserted, got %d bids", len(*inserted))
+	}
+}
+
+func TestCreateBidRejectsBidLowerThanHighest(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	ctx := context.Background()
+
+	repo.CreateBid(ctx, []bid_entity.Bid{{Id: "1", UserId: "alice", AuctionId: "auction", Amount: 100, Timestamp: time.Now()}})
+
+	err := repo.CreateBid(ctx, []bid_entity.Bid{{Id: "2", UserId: "bob", AuctionId: "auction", Amount: 90, Timestamp: time.Now()}})
+	if err == nil || err.Err != "bad_request" {
+		t.Fatalf("Expected a bad request for a lower bid, got %v", err)
+	}
+
+	if len(*inserted) != 1 {
+		t.Errorf("Expected the lower bid not to be inserted, got %d bids", len(*inserted))
+	}
+}
+
+func TestCreateBidRequiresMinimumIncrement(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	repo.minBidIncrement = 10
+	ctx := context.Background()
+
+	repo.CreateBid(ctx, []bid_entity.Bid{{Id: "1", UserId: "alice", AuctionId: "auction", Amount: 100, Timestamp: time.Now()}})
+
+	if err := repo.CreateBid(ctx, []bid_entity.Bid{{Id: "2", UserId: "bob", AuctionId: "auction", Amount: 105, Timestamp: time.Now()}}); err == nil {
+		t.Errorf("Expected a bid below the minimum increment to be rejected")
+	}
+
+	if err := repo.CreateBid(ctx, []bid_entity.Bid{{Id: "3", UserId: "bob", AuctionId: "auction", Amount: 110, Timestamp: time.Now()}}); err != nil {
+		t.Fatalf("Expected a bid with the minimum increment to be accepted, got %v", err)
+	}
+
+	if len(*inserted) != 2 || (*inserted)[1].Id != "3" {
+		t.Errorf("Expected only the sufficiently higher bid to be inserted, got %+v", *inserted)
+	}
+}
+
+func TestGetMinBidIncrement(t *testing.T) {
+	os.Setenv("BID_MIN_INCREMENT", "2.5")
+	if value := getMinBidIncrement(); value != 2.5 {
+		t.Errorf("Expected increment 2.5, got %v", value)
+	}
+
+	os.Setenv("BID_MIN_INCREMENT", "-1")
+	if value := getMinBidIncrement(); value != 0 {
+		t.Errorf("Expected default increment 0, got %v", value)
+	}
+
+	os.Unsetenv("BID_MIN_INCREMENT")
+}
+
+func TestCreateBidEnforcesStartingBid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   float64
+		accepted bool
+	}{
+		{name: "below", amount: 99.99, accepted: false},
+		{name: "equal", amount: 100, accepted: true},
+		{name: "above", amount: 150, accepted: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, inserted := setupInMemoryBidRepository("auction")
+			repo.auctionStartingBidMap["auction"] = 100
+
+			err := repo.CreateBid(context.Background(), []bid_entity.Bid{
+				{Id: "1", UserId: "alice", AuctionId: "auction", Amount: tc.amount, Timestamp: time.Now()},
+			})
+
+			if tc.accepted {
+				if err != nil || len(*inserted) != 1 {
+					t.Errorf("Expected bid of %.2f to be accepted, got %v (%d bids)", tc.amount, err, len(*inserted))
+				}
+				return
+			}
+
+			if err == nil || err.Err != "bad_request" || err.Code != "bid.below_starting_bid" {
+				t.Errorf("Expected bid below the starting bid to be rejected, got %v", err)
+			}
+
+			if len(*inserted) != 0 {
+				t.Errorf("Expected no bids to be inserted, got %d", len(*inserted))
+			}
+		})
+	}
+}
+
+func TestAuctionChangesInvalidateCachedAuction(t *testing.T) {
+	now := time.Now()
+	auctionRepository := auction.NewInMemoryAuctionRepository(
+		auction.WithDuration(time.Minute),
+		auction.WithClock(auction.ClockFunc(func() time.Time { return now })))
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("Failed to create mongo client: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+	repo := NewBidRepository(client.Database("auctions"), auctionRepository)
+
+	auctionEntity, _ := auction_entity.CreateAuction("seller", "Phone", "Electronics", "A valid description", auction_entity.New)
+	if _, err := auctionRepository.CreateAuction(context.Background(), auctionEntity); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	for _, auctionId := range []string{auctionEntity.Id, "other"} {
+		repo.auctionStatusMap[auctionId] = auction_entity.Active
+		repo.auctionEndTimeMap[auctionId] = now.Add(time.Minute)
+		repo.auctionStartingBidMap[auctionId] = 10
+	}
+
+	// O fechamento pelo monitor muda o status do leilão
+	now = now.Add(2 * time.Minute)
+	auctionRepository.CloseAndFlush()
+
+	if _, cached := repo.auctionStatusMap[auctionEntity.Id]; cached {
+		t.Errorf("Expected the closed auction status to leave the cache")
+	}
+	if _, cached := repo.auctionEndTimeMap[auctionEntity.Id]; cached {
+		t.Errorf("Expected the closed auction end time to leave the cache")
+	}
+	if _, cached := repo.auctionStatusMap["other"]; !cached {
+		t.Errorf("Expected other auctions to stay cached")
+	}
+}
+
+func TestCheckBidAmountRejectsBidNotAboveHighest(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository("auction")
+	repo.highestBid = func(ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+		return &bid_entity.Bid{AuctionId: auctionId, Amount: 20}, nil
+	}
+
+	err := repo.CheckBidAmount(context.Background(), bid_entity.Bid{AuctionId: "auction", Amount: 20})
+	if err == nil || err.Code != "bid.too_low" {
+		t.Fatalf("Expected bid.too_low, got %v", err)
+	}
+
+	if err := repo.CheckBidAmount(context.Background(), bid_entity.Bid{AuctionId: "auction", Amount: 25}); err != nil {
+		t.Errorf("Expected higher bid to pass, got %v", err)
+	}
+}
+
+func TestCheckBidAmountRejectsBidBelowStartingBid(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository("auction")
+	repo.auctionStartingBidMap["auction"] = 50
+	ignoreHighestBid(repo)
+
+	err := repo.CheckBidAmount(context.Background(), bid_entity.Bid{AuctionId: "auction", Amount: 40})
+	if err == nil || err.Code != "bid.below_starting_bid" {
+		t.Fatalf("Expected bid.below_starting_bid, got %v", err)
+	}
+
+	if err := repo.CheckBidAmount(context.Background(), bid_entity.Bid{AuctionId: "auction", Amount: 50}); err != nil {
+		t.Errorf("Expected bid at the starting bid to pass, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+)
+
+type fakeExchangeRates map[string]float64
+
+func (f fakeExchangeRates) Rate(from, to string) (float64, error) {
+	rate, ok := f[from+"->"+to]
+	if !ok {
+		return 0, errors.New("rate not available")
+	}
+	return rate, nil
+}
+
+func TestFindWinningBidConvertsToBaseCurrency(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository("auction")
+	repo.exchangeRates = fakeExchangeRates{"USD->BRL": 5}
+
+	repo.CreateBid(context.Background(), []bid_entity.Bid{
+		{Id: "brl", UserId: "alice", AuctionId: "auction", Amount: 100, Currency: "BRL"},
+		{Id: "usd", UserId: "bob", AuctionId: "auction", Amount: 30, Currency: "USD"},
+	})
+
+	winner, err := repo.FindWinningBidByAuctionId(context.Background(), "auction")
+	if err != nil {
+		t.Fatalf("Expected a winning bid, got %v", err)
+	}
+
+	if winner.Id != "usd" {
+		t.Errorf("Expected the USD bid worth 150 BRL to win, got %s", winner.Id)
+	}
+}
+
+func TestFindWinningBidWithoutComparableBids(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	repo.exchangeRates = fakeExchangeRates{}
+
+	// Lance gravado antes de a moeda perder a cotação
+	*inserted = append(*inserted, BidEntityMongo{
+		Id: "eur", UserId: "alice", AuctionId: "auction", Amount: 10, Currency: "EUR",
+	})
+
+	_, err := repo.FindWinningBidByAuctionId(context.Background(), "auction")
+	if err == nil || err.Err != "not_found" {
+		t.Fatalf("Expected not_found when no bid can be converted, got %v", err)
+	}
+}
+
+func TestCreateBidRejectsUnconvertibleCurrency(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	repo.exchangeRates = fakeExchangeRates{"USD->BRL": 5}
+
+	err := repo.CreateBid(context.Background(), []bid_entity.Bid{
+		{Id: "eur", UserId: "alice", AuctionId: "auction", Amount: 1000, Currency: "EUR"},
+	})
+	if err == nil || err.Code != "bid.unsupported_currency" {
+		t.Fatalf("Expected bid.unsupported_currency, got %v", err)
+	}
+
+	if len(*inserted) != 0 {
+		t.Errorf("Expected the unconvertible bid not to be inserted, got %d bids", len(*inserted))
+	}
+
+	checkErr := repo.CheckBidAmount(context.Background(),
+		bid_entity.Bid{AuctionId: "auction", Amount: 1000, Currency: "EUR"})
+	if checkErr == nil || checkErr.Code != "bid.unsupported_currency" {
+		t.Errorf("Expected CheckBidAmount to reject the currency, got %v", checkErr)
+	}
+}
+
+func TestCreateBidStoresBaseAmount(t *testing.T) {
+	repo, inserted := setupInMemoryBidRepository("auction")
+	repo.exchangeRates = fakeExchangeRates{"USD->BRL": 5}
+
+	repo.CreateBid(context.Background(), []bid_entity.Bid{
+		{Id: "usd", UserId: "bob", AuctionId: "auction", Amount: 30, Currency: "USD"},
+	})
+
+	if len(*inserted) != 1 || (*inserted)[0].BaseAmount != 150 {
+		t.Fatalf("Expected the bid to be stored with base amount 150, got %+v", *inserted)
+	}
+}
@@ -2,20 +2,58 @@ package bid
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"time"
 )
 
 func (bd *BidRepository) FindBidByAuctionId(
-	ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
-	filter := bson.M{"auctionId": auctionId}
+	ctx context.Context,
+	auctionId string,
+	opts bid_entity.FindBidsOptions) ([]bid_entity.Bid, *internal_error.InternalError) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	return bd.findBidsByFilter(ctx, auctionId, bson.M{"auction_id": auctionId}, findBidsOptions(opts))
+}
+
+// FindBidsByAuctionId retorna o histórico de lances do leilão em ordem
+// cronológica, com no máximo limit lances (0 usa todos); retorna
+// NotFoundError quando o leilão não tem lances
+func (bd *BidRepository) FindBidsByAuctionId(
+	ctx context.Context,
+	auctionId string,
+	limit int64) ([]bid_entity.Bid, *internal_error.InternalError) {
+	if err := (bid_entity.FindBidsOptions{Limit: limit}).Validate(); err != nil {
+		return nil, err
+	}
+
+	bids, err := bd.findBidsByFilter(ctx, auctionId, bson.M{"auction_id": auctionId}, bidHistoryOptions(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bids) == 0 {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("No bids found for auctionId %s", auctionId))
+	}
+
+	return bids, nil
+}
 
-	cursor, err := bd.Collection.Find(ctx, filter)
+// Executa a consulta e converte os documentos em entidades
+func (bd *BidRepository) findBidsByFilter(
+	ctx context.Context,
+	auctionId string,
+	filter bson.M,
+	opts *options.FindOptions) ([]bid_entity.Bid, *internal_error.InternalError) {
+	cursor, err := bd.Collection.Find(ctx, filter, opts)
 	if err != nil {
 		logger.Error(
 			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId), err)
@@ -33,34 +71,93 @@ func (bd *BidRepository) FindBidByAuctionId(
 
 	var bidEntities []bid_entity.Bid
 	for _, bidEntityMongo := range bidEntitiesMongo {
-		bidEntities = append(bidEntities, bid_entity.Bid{
-			Id:        bidEntityMongo.Id,
-			UserId:    bidEntityMongo.UserId,
-			AuctionId: bidEntityMongo.AuctionId,
-			Amount:    bidEntityMongo.Amount,
-			Timestamp: time.Unix(bidEntityMongo.Timestamp, 0),
-		})
+		bidEntities = append(bidEntities, *bidEntityMongo.ToEntity())
 	}
 
 	return bidEntities, nil
 }
 
+// Ordena do lance mais antigo ao mais recente (desempate pelo id) e aplica
+// o limite
+func bidHistoryOptions(limit int64) *options.FindOptions {
+	findOptions := options.Find().SetSort(bson.D{
+		{Key: "timestamp", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+	if limit > 0 {
+		findOptions.SetLimit(limit)
+	}
+
+	return findOptions
+}
+
+// Ordena por valor (desempate pelo lance mais antigo) e aplica o limite
+func findBidsOptions(opts bid_entity.FindBidsOptions) *options.FindOptions {
+	direction := -1
+	if opts.Ascending {
+		direction = 1
+	}
+
+	findOptions := options.Find().SetSort(bson.D{
+		{Key: "amount", Value: direction},
+		{Key: "timestamp", Value: 1},
+	})
+	if opts.Limit > 0 {
+		findOptions.SetLimit(opts.Limit)
+	}
+
+	return findOptions
+}
+
+// FindWinningBidByAuctionId retorna o maior lance do leilão; em caso de
+// empate vence o lance mais antigo. Retorna NotFoundError sem lances
 func (bd *BidRepository) FindWinningBidByAuctionId(
 	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
-	filter := bson.M{"auction_id": auctionId}
+	// Com conversor de câmbio o maior lance é calculado na moeda base
+	if bd.exchangeRates != nil {
+		return bd.findWinningBidInBaseCurrency(ctx, auctionId)
+	}
+
+	filter := bson.M{
+		"auction_id": auctionId,
+		"$or": bson.A{
+			bson.M{"currency": bd.baseCurrency},
+			bson.M{"currency": bson.M{"$exists": false}},
+		},
+	}
 
 	var bidEntityMongo BidEntityMongo
-	opts := options.FindOne().SetSort(bson.D{{"amount", -1}})
+	opts := options.FindOne().SetSort(winningBidSort())
 	if err := bd.Collection.FindOne(ctx, filter, opts).Decode(&bidEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("No bids found for auction %s", auctionId))
+		}
+
 		logger.Error("Error trying to find the auction winner", err)
 		return nil, internal_error.NewInternalServerError("Error trying to find the auction winner")
 	}
 
-	return &bid_entity.Bid{
-		Id:        bidEntityMongo.Id,
-		UserId:    bidEntityMongo.UserId,
-		AuctionId: bidEntityMongo.AuctionId,
-		Amount:    bidEntityMongo.Amount,
-		Timestamp: time.Unix(bidEntityMongo.Timestamp, 0),
-	}, nil
+	return bidEntityMongo.ToEntity(), nil
+}
+
+// Maior valor primeiro; no empate, o lance mais antigo
+func winningBidSort() bson.D {
+	return bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}}
+}
+
+func (bd *BidRepository) findWinningBidInBaseCurrency(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	bids, err := bd.findBids(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	winner := bid_entity.HighestBid(bids, bd.baseCurrency, bd.exchangeRates)
+	if winner == nil {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("No comparable bids found for auction %s", auctionId))
+	}
+
+	return winner, nil
 }
@@ -0,0 +1,165 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFindBidsOptions(t *testing.T) {
+	opts := findBidsOptions(bid_entity.FindBidsOptions{Limit: 3})
+
+	expectedSort := bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}}
+	if !reflect.DeepEqual(opts.Sort, expectedSort) {
+		t.Errorf("Expected default sort %v, got %v", expectedSort, opts.Sort)
+	}
+	if opts.Limit == nil || *opts.Limit != 3 {
+		t.Errorf("Expected limit 3, got %v", opts.Limit)
+	}
+
+	opts = findBidsOptions(bid_entity.FindBidsOptions{Ascending: true})
+	if sort := opts.Sort.(bson.D); sort[0].Value != 1 {
+		t.Errorf("Expected ascending sort, got %v", sort)
+	}
+	if opts.Limit != nil {
+		t.Errorf("Expected no limit, got %v", *opts.Limit)
+	}
+}
+
+func TestFindBidByAuctionIdRejectsInvalidLimit(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository()
+
+	for _, limit := range []int64{-1, bid_entity.MaxFindBidsLimit + 1} {
+		_, err := repo.FindBidByAuctionId(context.Background(), "auction",
+			bid_entity.FindBidsOptions{Limit: limit})
+		if err == nil || err.Code != "limit.invalid" {
+			t.Errorf("Expected limit.invalid for limit %d, got %v", limit, err)
+		}
+	}
+}
+
+func TestFindBidByAuctionIdReturnsTopBids(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := &BidRepository{Collection: database.Collection("bids")}
+
+	seed := []interface{}{
+		BidEntityMongo{Id: "1", AuctionId: "auction", Amount: 10},
+		BidEntityMongo{Id: "2", AuctionId: "auction", Amount: 40},
+		BidEntityMongo{Id: "3", AuctionId: "auction", Amount: 30},
+		BidEntityMongo{Id: "4", AuctionId: "auction", Amount: 20},
+		BidEntityMongo{Id: "5", AuctionId: "other", Amount: 100},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seed); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	bids, err := repo.FindBidByAuctionId(ctx, "auction", bid_entity.FindBidsOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("Expected bids, got %v", err)
+	}
+
+	if len(bids) != 2 || bids[0].Id != "2" || bids[1].Id != "3" {
+		t.Errorf("Expected the top 2 bids [2 3] in order, got %+v", bids)
+	}
+}
+
+func TestBidHistoryOptions(t *testing.T) {
+	opts := bidHistoryOptions(5)
+
+	expectedSort := bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}
+	if !reflect.DeepEqual(opts.Sort, expectedSort) {
+		t.Errorf("Expected chronological sort %v, got %v", expectedSort, opts.Sort)
+	}
+	if opts.Limit == nil || *opts.Limit != 5 {
+		t.Errorf("Expected limit 5, got %v", opts.Limit)
+	}
+
+	if opts := bidHistoryOptions(0); opts.Limit != nil {
+		t.Errorf("Expected no limit, got %v", *opts.Limit)
+	}
+}
+
+func TestFindBidsByAuctionIdRejectsInvalidLimit(t *testing.T) {
+	repo, _ := setupInMemoryBidRepository()
+
+	if _, err := repo.FindBidsByAuctionId(context.Background(), "auction", -1); err == nil || err.Code != "limit.invalid" {
+		t.Errorf("Expected limit.invalid for a negative limit, got %v", err)
+	}
+}
+
+func TestFindBidsByAuctionIdReturnsHistory(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := &BidRepository{Collection: database.Collection("bids")}
+
+	now := time.Now()
+	seed := []interface{}{
+		BidEntityMongo{Id: "third", AuctionId: "auction", Amount: 30, Timestamp: now.Unix()},
+		BidEntityMongo{Id: "first", AuctionId: "auction", Amount: 10, Timestamp: now.Add(-2 * time.Minute).Unix()},
+		BidEntityMongo{Id: "other", AuctionId: "other", Amount: 100, Timestamp: now.Add(-time.Hour).Unix()},
+		BidEntityMongo{Id: "second", AuctionId: "auction", Amount: 20, Timestamp: now.Add(-time.Minute).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seed); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	bids, err := repo.FindBidsByAuctionId(ctx, "auction", 0)
+	if err != nil {
+		t.Fatalf("Expected bid history, got %v", err)
+	}
+
+	if len(bids) != 3 || bids[0].Id != "first" || bids[1].Id != "second" || bids[2].Id != "third" {
+		t.Errorf("Expected the auction bids [first second third] in order, got %+v", bids)
+	}
+
+	if bids, err := repo.FindBidsByAuctionId(ctx, "auction", 2); err != nil || len(bids) != 2 || bids[1].Id != "second" {
+		t.Errorf("Expected the 2 oldest bids, got %+v (%v)", bids, err)
+	}
+
+	if _, err := repo.FindBidsByAuctionId(ctx, "no-bids", 0); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for an auction without bids, got %v", err)
+	}
+}
+
+func TestWinningBidSort(t *testing.T) {
+	expected := bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}}
+	if sort := winningBidSort(); !reflect.DeepEqual(sort, expected) {
+		t.Errorf("Expected sort %v, got %v", expected, sort)
+	}
+}
+
+func TestFindWinningBidByAuctionIdTieGoesToEarliest(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	repo := &BidRepository{Collection: database.Collection("bids"), baseCurrency: "BRL"}
+
+	now := time.Now()
+	seed := []interface{}{
+		BidEntityMongo{Id: "later", AuctionId: "auction", Amount: 50, Timestamp: now.Unix()},
+		BidEntityMongo{Id: "earliest", AuctionId: "auction", Amount: 50, Timestamp: now.Add(-time.Minute).Unix()},
+		BidEntityMongo{Id: "lower", AuctionId: "auction", Amount: 40, Timestamp: now.Add(-time.Hour).Unix()},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, seed); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	winner, err := repo.FindWinningBidByAuctionId(ctx, "auction")
+	if err != nil {
+		t.Fatalf("Expected a winning bid, got %v", err)
+	}
+	if winner.Id != "earliest" {
+		t.Errorf("Expected the earliest of the tied bids to win, got %s", winner.Id)
+	}
+
+	if _, err := repo.FindWinningBidByAuctionId(ctx, "no-bids"); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for an auction without bids, got %v", err)
+	}
+}
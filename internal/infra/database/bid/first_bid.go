@@ -0,0 +1,78 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CategoryTimeToFirstBid struct {
+	Category string
+	Average  time.Duration
+	Auctions int64
+}
+
+type categoryTimeToFirstBidMongo struct {
+	Category       string  `bson:"_id"`
+	AverageSeconds float64 `bson:"average_seconds"`
+	Auctions       int64   `bson:"auctions"`
+}
+
+// AverageTimeToFirstBid calcula, por categoria, o tempo médio entre a
+// criação do leilão e o seu primeiro lance; leilões sem lances ficam de fora
+func (bd *BidRepository) AverageTimeToFirstBid(
+	ctx context.Context) ([]CategoryTimeToFirstBid, *internal_error.InternalError) {
+	cursor, err := bd.Collection.Aggregate(ctx, averageTimeToFirstBidPipeline())
+	if err != nil {
+		logger.Error("Error trying to aggregate time to first bid", err)
+		return nil, internal_error.NewInternalServerError("Error trying to aggregate time to first bid")
+	}
+	defer cursor.Close(ctx)
+
+	var resultsMongo []categoryTimeToFirstBidMongo
+	if err := cursor.All(ctx, &resultsMongo); err != nil {
+		logger.Error("Error trying to decode time to first bid", err)
+		return nil, internal_error.NewInternalServerError("Error trying to decode time to first bid")
+	}
+
+	results := []CategoryTimeToFirstBid{}
+	for _, result := range resultsMongo {
+		results = append(results, result.toEntity())
+	}
+
+	return results, nil
+}
+
+func (cm categoryTimeToFirstBidMongo) toEntity() CategoryTimeToFirstBid {
+	return CategoryTimeToFirstBid{
+		Category: cm.Category,
+		Average:  time.Duration(cm.AverageSeconds * float64(time.Second)),
+		Auctions: cm.Auctions,
+	}
+}
+
+func averageTimeToFirstBidPipeline() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":       "$auction_id",
+			"first_bid": bson.M{"$min": "$timestamp"},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "auctions",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "auction",
+		}}},
+		{{Key: "$unwind", Value: "$auction"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             "$auction.category",
+			"average_seconds": bson.M{"$avg": bson.M{"$subtract": bson.A{"$first_bid", "$auction.timestamp"}}},
+			"auctions":        bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+}
@@ -0,0 +1,62 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"testing"
+	"time"
+)
+
+func TestCategoryTimeToFirstBidToEntity(t *testing.T) {
+	result := categoryTimeToFirstBidMongo{Category: "books", AverageSeconds: 90.5, Auctions: 2}.toEntity()
+
+	if result.Average != 90*time.Second+500*time.Millisecond {
+		t.Errorf("Expected 1m30.5s, got %v", result.Average)
+	}
+}
+
+func TestAverageTimeToFirstBid(t *testing.T) {
+	database := setupMongoDatabase(t)
+	ctx := context.Background()
+
+	auctionRepository := auction.NewAuctionRepository(database)
+	repo := NewBidRepository(database, auctionRepository)
+
+	created := time.Now().Add(-time.Hour).Unix()
+	auctions := []interface{}{
+		auction.AuctionEntityMongo{Id: "book-1", Category: "books", Timestamp: created},
+		auction.AuctionEntityMongo{Id: "book-2", Category: "books", Timestamp: created},
+		auction.AuctionEntityMongo{Id: "toy-1", Category: "toys", Timestamp: created},
+		auction.AuctionEntityMongo{Id: "no-bids", Category: "toys", Timestamp: created},
+	}
+	if _, err := auctionRepository.Collection.InsertMany(ctx, auctions); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	bids := []interface{}{
+		BidEntityMongo{Id: "1", AuctionId: "book-1", Amount: 10, Timestamp: created + 60},
+		BidEntityMongo{Id: "2", AuctionId: "book-1", Amount: 20, Timestamp: created + 600},
+		BidEntityMongo{Id: "3", AuctionId: "book-2", Amount: 10, Timestamp: created + 180},
+		BidEntityMongo{Id: "4", AuctionId: "toy-1", Amount: 10, Timestamp: created + 30},
+	}
+	if _, err := repo.Collection.InsertMany(ctx, bids); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	results, err := repo.AverageTimeToFirstBid(ctx)
+	if err != nil {
+		t.Fatalf("Failed to aggregate time to first bid: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 categories, got %+v", results)
+	}
+
+	if results[0].Category != "books" || results[0].Average != 2*time.Minute || results[0].Auctions != 2 {
+		t.Errorf("Expected books to average 2m over 2 auctions, got %+v", results[0])
+	}
+
+	if results[1].Category != "toys" || results[1].Average != 30*time.Second || results[1].Auctions != 1 {
+		t.Errorf("Expected toys to average 30s over 1 auction, got %+v", results[1])
+	}
+}
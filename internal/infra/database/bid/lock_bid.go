@@ -0,0 +1,52 @@
+package bid
+
+import "sync"
+
+// Locks por leilão: lances de leilões diferentes não esperam uns pelos
+// outros. Cada lock é removido do mapa quando ninguém mais o usa
+type auctionLocks struct {
+	mutex sync.Mutex
+	locks map[string]*auctionLock
+}
+
+type auctionLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newAuctionLocks() *auctionLocks {
+	return &auctionLocks{locks: make(map[string]*auctionLock)}
+}
+
+// Lock bloqueia o leilão e retorna a função que o libera
+func (al *auctionLocks) Lock(auctionId string) (unlock func()) {
+	al.mutex.Lock()
+	lock, ok := al.locks[auctionId]
+	if !ok {
+		lock = &auctionLock{}
+		al.locks[auctionId] = lock
+	}
+	lock.refs++
+	al.mutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		al.mutex.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(al.locks, auctionId)
+		}
+		al.mutex.Unlock()
+	}
+}
+
+// Informa se o leilão está bloqueado ou aguardado por algum lance
+func (al *auctionLocks) isLocked(auctionId string) bool {
+	al.mutex.Lock()
+	defer al.mutex.Unlock()
+
+	_, ok := al.locks[auctionId]
+	return ok
+}
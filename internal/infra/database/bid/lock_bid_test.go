@@ -0,0 +1,49 @@
+package bid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuctionLocksAreIndependentPerAuction(t *testing.T) {
+	locks := newAuctionLocks()
+
+	unlockFirst := locks.Lock("first")
+
+	// Outro leilão não espera pelo lock do primeiro
+	done := make(chan struct{})
+	go func() {
+		unlock := locks.Lock("second")
+		unlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a different auction not to wait for the lock")
+	}
+
+	// O mesmo leilão espera até o lock ser liberado
+	acquired := make(chan struct{})
+	go func() {
+		unlock := locks.Lock("first")
+		close(acquired)
+		unlock()
+	}()
+	select {
+	case <-acquired:
+		t.Fatalf("Expected the same auction to wait for the lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlockFirst()
+	<-acquired
+
+	deadline := time.Now().Add(time.Second)
+	for locks.isLocked("first") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if locks.isLocked("first") || locks.isLocked("second") {
+		t.Errorf("Expected released locks to leave the map")
+	}
+}
@@ -12,8 +12,9 @@ import (
 )
 
 type UserEntityMongo struct {
-	Id   string `bson:"_id"`
-	Name string `bson:"name"`
+	Id       string `bson:"_id"`
+	Name     string `bson:"name"`
+	Verified bool   `bson:"verified"`
 }
 
 type UserRepository struct {
@@ -34,9 +35,9 @@ func (ur *UserRepository) FindUserById(
 	err := ur.Collection.FindOne(ctx, filter).Decode(&userEntityMongo)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			logger.Error(fmt.Sprintf("User not found with this id = %d", userId), err)
+			logger.Error(fmt.Sprintf("User not found with this id = %s", userId), err)
 			return nil, internal_error.NewNotFoundError(
-				fmt.Sprintf("User not found with this id = %d", userId))
+				fmt.Sprintf("User not found with this id = %s", userId))
 		}
 
 		logger.Error("Error trying to find user by userId", err)
@@ -44,9 +45,24 @@ func (ur *UserRepository) FindUserById(
 	}
 
 	userEntity := &user_entity.User{
-		Id:   userEntityMongo.Id,
-		Name: userEntityMongo.Name,
+		Id:       userEntityMongo.Id,
+		Name:     userEntityMongo.Name,
+		Verified: userEntityMongo.Verified,
 	}
 
 	return userEntity, nil
 }
+
+// IsVerified implementa user_entity.UserVerifier; usuários inexistentes
+// são tratados como não verificados
+func (ur *UserRepository) IsVerified(ctx context.Context, userId string) (bool, error) {
+	userEntity, err := ur.FindUserById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, internal_error.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return userEntity.Verified, nil
+}
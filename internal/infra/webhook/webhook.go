@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Cabeçalho com a assinatura HMAC-SHA256 do corpo, no formato "sha256=<hex>"
+const SignatureHeader = "X-Auction-Signature"
+
+// Sender entrega eventos via POST assinados com um segredo compartilhado,
+// repetindo a entrega quando o destino não responde com 2xx
+type Sender struct {
+	URL        string
+	Secret     string
+	MaxRetries int
+	RetryDelay time.Duration
+	client     *http.Client
+}
+
+// NewSenderFromEnv lê AUCTION_WEBHOOK_URL e AUCTION_WEBHOOK_SECRET; retorna
+// nil quando a URL não está configurada e erro quando ela está configurada
+// sem segredo, já que o destino não conseguiria validar a assinatura
+func NewSenderFromEnv() (*Sender, error) {
+	url := os.Getenv("AUCTION_WEBHOOK_URL")
+	if url == "" {
+		return nil, nil
+	}
+
+	secret := os.Getenv("AUCTION_WEBHOOK_SECRET")
+	if secret == "" {
+		return nil, errors.New("AUCTION_WEBHOOK_SECRET is required when AUCTION_WEBHOOK_URL is set")
+	}
+
+	return NewSender(url, secret), nil
+}
+
+func NewSender(url, secret string) *Sender {
+	return &Sender{
+		URL:        url,
+		Secret:     secret,
+		MaxRetries: getMaxRetries(),
+		RetryDelay: time.Second,
+		client:     &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Sign calcula a assinatura enviada em SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send serializa o evento e tenta entregá-lo até MaxRetries vezes além da
+// primeira tentativa, esperando RetryDelay (dobrado a cada falha) entre elas
+func (s *Sender) Send(ctx context.Context, event interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error encoding webhook payload: %w", err)
+	}
+
+	signature := Sign(s.Secret, body)
+	delay := s.RetryDelay
+
+	for attempt := 0; ; attempt++ {
+		err = s.deliver(ctx, body, signature)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= s.MaxRetries {
+			return fmt.Errorf("webhook delivery failed after %d attempts: %w", attempt+1, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// Notify entrega o encerramento de um leilão, implementando auction.Notifier
+func (s *Sender) Notify(ctx context.Context, event auction.AuctionClosedEvent) error {
+	return s.Send(ctx, event)
+}
+
+func (s *Sender) deliver(ctx context.Context, body []byte, signature string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, signature)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+// Lê AUCTION_WEBHOOK_MAX_RETRIES (padrão: 3)
+func getMaxRetries() int {
+	value, err := strconv.Atoi(os.Getenv("AUCTION_WEBHOOK_MAX_RETRIES"))
+	if err != nil || value < 0 {
+		return 3
+	}
+
+	return value
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendSignsPayload(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+	}))
+	defer server.Close()
+
+	sender := NewSender(server.URL, "shared-secret")
+	if err := sender.Send(context.Background(), map[string]string{"auction_id": "auction"}); err != nil {
+		t.Fatalf("Expected delivery to succeed, got %v", err)
+	}
+
+	if string(body) != `{"auction_id":"auction"}` {
+		t.Errorf("Unexpected payload %s", body)
+	}
+
+	mac := hmac.New(sha256.New, []byte("shared-secret"))
+	mac.Write(body)
+	if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != expected {
+		t.Errorf("Expected signature %s, got %s", expected, signature)
+	}
+}
+
+func TestSendRetriesOnFailure(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	sender := NewSender(server.URL, "secret")
+	sender.RetryDelay = time.Millisecond
+
+	if err := sender.Send(context.Background(), "event"); err != nil {
+		t.Fatalf("Expected delivery to succeed on the third attempt, got %v", err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestSendGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sender := NewSender(server.URL, "secret")
+	sender.MaxRetries = 2
+	sender.RetryDelay = time.Millisecond
+
+	if err := sender.Send(context.Background(), "event"); err == nil {
+		t.Fatalf("Expected delivery to fail")
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d", attempts)
+	}
+}
+
+func TestNotifyDeliversClosedAuctionAfterServerError(t *testing.T) {
+	var attempts int32
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	sender := NewSender(server.URL, "secret")
+	sender.RetryDelay = time.Millisecond
+
+	closedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := auction.AuctionClosedEvent{
+		AuctionId: "auction", WinnerUserId: "winner", FinalPrice: 250.5, ClosedAt: closedAt}
+	if err := sender.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Expected delivery to succeed after a retry, got %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+
+	expected := map[string]interface{}{
+		"auction_id":     "auction",
+		"winner_user_id": "winner",
+		"final_price":    250.5,
+		"closed_at":      "2024-01-01T12:00:00Z",
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Errorf("Expected payload %v, got %v", expected, payload)
+	}
+}
+
+func TestNewSenderFromEnvRequiresSecret(t *testing.T) {
+	os.Setenv("AUCTION_WEBHOOK_URL", "http://example.com/hook")
+	defer os.Unsetenv("AUCTION_WEBHOOK_URL")
+	defer os.Unsetenv("AUCTION_WEBHOOK_SECRET")
+
+	if sender, err := NewSenderFromEnv(); err == nil || sender != nil {
+		t.Errorf("Expected an error for a URL without secret, got %v, %v", sender, err)
+	}
+
+	os.Setenv("AUCTION_WEBHOOK_SECRET", "secret")
+	if sender, err := NewSenderFromEnv(); err != nil || sender == nil || sender.Secret != "secret" {
+		t.Errorf("Expected a sender with the secret, got %v, %v", sender, err)
+	}
+
+	os.Unsetenv("AUCTION_WEBHOOK_URL")
+	if sender, err := NewSenderFromEnv(); err != nil || sender != nil {
+		t.Errorf("Expected no sender without a URL, got %v, %v", sender, err)
+	}
+}
@@ -1,31 +1,84 @@
 package internal_error
 
+import "errors"
+
+// Tipos de erro, gravados em InternalError.Err
+const (
+	NotFound           = "not_found"
+	BadRequest         = "bad_request"
+	InternalServer     = "internal_server_error"
+	ServiceUnavailable = "service_unavailable"
+)
+
+// Sentinelas para comparar o tipo do erro com errors.Is, ex.:
+// errors.Is(err, internal_error.ErrNotFound)
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrBadRequest         = errors.New("bad request")
+	ErrInternalServer     = errors.New("internal server error")
+	ErrServiceUnavailable = errors.New("service unavailable")
+)
+
+var kindSentinels = map[string]error{
+	NotFound:           ErrNotFound,
+	BadRequest:         ErrBadRequest,
+	InternalServer:     ErrInternalServer,
+	ServiceUnavailable: ErrServiceUnavailable,
+}
+
 type InternalError struct {
 	Message string
 	Err     string
+	Code    string
 }
 
 func (ie *InternalError) Error() string {
 	return ie.Message
 }
 
+// Is faz errors.Is reconhecer a sentinela correspondente ao tipo do erro
+func (ie *InternalError) Is(target error) bool {
+	if ie == nil {
+		return false
+	}
+
+	sentinel, known := kindSentinels[ie.Err]
+	return known && sentinel == target
+}
+
+// WithCode anexa um código estável (ex.: "product_name.too_short") que o
+// frontend pode usar para traduzir a mensagem
+func (ie *InternalError) WithCode(code string) *InternalError {
+	ie.Code = code
+	return ie
+}
+
 func NewNotFoundError(message string) *InternalError {
 	return &InternalError{
 		Message: message,
-		Err:     "not_found",
+		Err:     NotFound,
 	}
 }
 
 func NewInternalServerError(message string) *InternalError {
 	return &InternalError{
 		Message: message,
-		Err:     "internal_server_error",
+		Err:     InternalServer,
 	}
 }
 
 func NewBadRequestError(message string) *InternalError {
 	return &InternalError{
 		Message: message,
-		Err:     "bad_request",
+		Err:     BadRequest,
+	}
+}
+
+// Indica que uma dependência (ex.: o MongoDB) está inacessível; a operação
+// pode ser repetida mais tarde
+func NewServiceUnavailableError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     ServiceUnavailable,
 	}
 }
@@ -0,0 +1,41 @@
+package internal_error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorsIsMatchesKind(t *testing.T) {
+	testCases := []struct {
+		err      *InternalError
+		sentinel error
+	}{
+		{NewNotFoundError("missing"), ErrNotFound},
+		{NewBadRequestError("invalid").WithCode("field.invalid"), ErrBadRequest},
+		{NewInternalServerError("failed"), ErrInternalServer},
+		{NewServiceUnavailableError("unavailable"), ErrServiceUnavailable},
+	}
+
+	sentinels := []error{ErrNotFound, ErrBadRequest, ErrInternalServer, ErrServiceUnavailable}
+	for _, tc := range testCases {
+		for _, sentinel := range sentinels {
+			if got, expected := errors.Is(tc.err, sentinel), sentinel == tc.sentinel; got != expected {
+				t.Errorf("errors.Is(%s error, %v) = %v, expected %v", tc.err.Err, sentinel, got, expected)
+			}
+		}
+	}
+}
+
+func TestErrorsIsThroughWrapping(t *testing.T) {
+	wrapped := fmt.Errorf("loading auction: %w", NewNotFoundError("missing"))
+
+	if !errors.Is(wrapped, ErrNotFound) {
+		t.Errorf("Expected wrapped error to match ErrNotFound")
+	}
+
+	var nilError *InternalError
+	if errors.Is(nilError, ErrNotFound) {
+		t.Errorf("Expected nil error not to match any kind")
+	}
+}
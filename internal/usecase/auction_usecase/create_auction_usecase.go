@@ -6,24 +6,56 @@ import (
 	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"os"
+	"strings"
 	"time"
 )
 
 type AuctionInputDTO struct {
-	ProductName string           `json:"product_name" binding:"required,min=1"`
-	Category    string           `json:"category" binding:"required,min=2"`
-	Description string           `json:"description" binding:"required,min=10,max=200"`
+	SellerId    string   `json:"seller_id" binding:"required"`
+	ProductName string   `json:"product_name" binding:"required,min=1"`
+	Category    string   `json:"category" binding:"required,min=2"`
+	Categories  []string `json:"categories"`
+	// O tamanho máximo (MaxDescriptionLength) é validado pela entidade
+	Description string           `json:"description" binding:"required,min=10"`
 	Condition   ProductCondition `json:"condition" binding:"oneof=0 1 2"`
+
+	NotificationTemplateId string            `json:"notification_template_id"`
+	NotificationMetadata   map[string]string `json:"notification_metadata"`
+
+	// Opcional; encerra o leilão para quem aceitar pagar este valor
+	BuyNowPrice float64 `json:"buy_now_price"`
+	// Opcional; lances abaixo deste valor não vencem o leilão
+	ReservePrice float64 `json:"reserve_price"`
+	// Opcional; lances abaixo deste valor são recusados
+	StartingBid float64 `json:"starting_bid"`
+	// Opcional; duração do leilão em segundos, no lugar da padrão
+	DurationSeconds int64 `json:"duration_seconds"`
+	// Opcional; agenda o início do leilão (RFC 3339)
+	StartTime time.Time `json:"start_time"`
+	// Opcional; vem do cabeçalho Idempotency-Key
+	IdempotencyKey string `json:"-"`
 }
 
 type AuctionOutputDTO struct {
 	Id          string           `json:"id"`
+	SellerId    string           `json:"seller_id,omitempty"`
 	ProductName string           `json:"product_name"`
 	Category    string           `json:"category"`
+	Categories  []string         `json:"categories,omitempty"`
 	Description string           `json:"description"`
 	Condition   ProductCondition `json:"condition"`
 	Status      AuctionStatus    `json:"status"`
 	Timestamp   time.Time        `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	// Calculado pelo relógio do servidor para ancorar a contagem regressiva
+	RemainingSeconds int64 `json:"remaining_seconds"`
+	// Término previsto; ausente para rascunhos e leilões agendados
+	EndTime       *time.Time `json:"end_time,omitempty"`
+	BuyNowPrice   float64    `json:"buy_now_price,omitempty"`
+	StartingBid   float64    `json:"starting_bid,omitempty"`
+	ReserveNotMet bool       `json:"reserve_not_met,omitempty"`
+	// Início agendado; ausente quando o leilão começou na criação
+	StartTime *time.Time `json:"start_time,omitempty"`
 }
 
 type WinningInfoOutputDTO struct {
@@ -37,21 +69,26 @@ func NewAuctionUseCase(
 	return &AuctionUseCase{
 		auctionRepositoryInterface: auctionRepositoryInterface,
 		bidRepositoryInterface:     bidRepositoryInterface,
+		notificationTemplates: auction_entity.ParseNotificationTemplates(
+			os.Getenv("AUCTION_NOTIFICATION_TEMPLATES")),
 	}
 }
 
 type AuctionUseCaseInterface interface {
 	CreateAuction(
 		ctx context.Context,
-		auctionInput AuctionInputDTO) *internal_error.InternalError
+		auctionInput AuctionInputDTO) (*AuctionOutputDTO, *internal_error.InternalError)
 
 	FindAuctionById(
 		ctx context.Context, id string) (*AuctionOutputDTO, *internal_error.InternalError)
 
 	FindAuctions(
 		ctx context.Context,
-		status AuctionStatus,
-		category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError)
+		status *AuctionStatus,
+		categories []string,
+		productName, search string,
+		from, to time.Time,
+		oldestFirst bool) ([]AuctionOutputDTO, *internal_error.InternalError)
 
 	FindWinningBidByAuctionId(
 		ctx context.Context,
@@ -64,24 +101,68 @@ type AuctionStatus int64
 type AuctionUseCase struct {
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
 	bidRepositoryInterface     bid_entity.BidEntityRepository
+	// Templates de notificação aceitos (AUCTION_NOTIFICATION_TEMPLATES)
+	notificationTemplates []string
 }
 
 func (au *AuctionUseCase) CreateAuction(
 	ctx context.Context,
-	auctionInput AuctionInputDTO) *internal_error.InternalError {
+	auctionInput AuctionInputDTO) (*AuctionOutputDTO, *internal_error.InternalError) {
 	auction, err := auction_entity.CreateAuction(
+		auctionInput.SellerId,
 		auctionInput.ProductName,
 		auctionInput.Category,
 		auctionInput.Description,
 		auction_entity.ProductCondition(auctionInput.Condition))
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	auction.IdempotencyKey = strings.TrimSpace(auctionInput.IdempotencyKey)
+
+	if auctionInput.NotificationTemplateId != "" {
+		if err := auction.SetNotificationTemplate(
+			auctionInput.NotificationTemplateId, auctionInput.NotificationMetadata,
+			au.notificationTemplates); err != nil {
+			return nil, err
+		}
+	}
+
+	if auctionInput.BuyNowPrice != 0 {
+		if err := auction.SetBuyNowPrice(auctionInput.BuyNowPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := auction.SetReservePrice(auctionInput.ReservePrice); err != nil {
+		return nil, err
 	}
 
-	if err := au.auctionRepositoryInterface.CreateAuction(
-		ctx, auction); err != nil {
-		return err
+	if err := auction.SetStartingBid(auctionInput.StartingBid); err != nil {
+		return nil, err
+	}
+
+	if err := auction.SetCategories(auctionInput.Categories); err != nil {
+		return nil, err
+	}
+
+	if auctionInput.DurationSeconds != 0 {
+		if err := auction.SetDuration(time.Duration(auctionInput.DurationSeconds) * time.Second); err != nil {
+			return nil, err
+		}
+	}
+
+	if !auctionInput.StartTime.IsZero() {
+		if err := auction.SetStartTime(auctionInput.StartTime); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := au.auctionRepositoryInterface.CreateAuction(ctx, auction)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	auctionOutputDTO := newAuctionOutputDTO(created, time.Now())
+	return &auctionOutputDTO, nil
 }
@@ -6,6 +6,7 @@ import (
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"time"
 )
 
 func (au *AuctionUseCase) FindAuctionById(
@@ -15,38 +16,38 @@ func (au *AuctionUseCase) FindAuctionById(
 		return nil, err
 	}
 
-	return &AuctionOutputDTO{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   ProductCondition(auctionEntity.Condition),
-		Status:      AuctionStatus(auctionEntity.Status),
-		Timestamp:   auctionEntity.Timestamp,
-	}, nil
+	auctionOutputDTO := newAuctionOutputDTO(auctionEntity, time.Now())
+	return &auctionOutputDTO, nil
 }
 
 func (au *AuctionUseCase) FindAuctions(
 	ctx context.Context,
-	status AuctionStatus,
-	category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError) {
+	status *AuctionStatus,
+	categories []string,
+	productName, search string,
+	from, to time.Time,
+	oldestFirst bool) ([]AuctionOutputDTO, *internal_error.InternalError) {
+	var statusFilter *auction_entity.AuctionStatus
+	if status != nil {
+		entityStatus := auction_entity.AuctionStatus(*status)
+		statusFilter = &entityStatus
+	}
+
+	sort := auction_entity.SortNewestFirst
+	if oldestFirst {
+		sort = auction_entity.SortOldestFirst
+	}
+
 	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(
-		ctx, auction_entity.AuctionStatus(status), category, productName)
+		ctx, statusFilter, categories, productName, search, from, to, sort)
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
 	var auctionOutputs []AuctionOutputDTO
-	for _, value := range auctionEntities {
-		auctionOutputs = append(auctionOutputs, AuctionOutputDTO{
-			Id:          value.Id,
-			ProductName: value.ProductName,
-			Category:    value.Category,
-			Description: value.Description,
-			Condition:   ProductCondition(value.Condition),
-			Status:      AuctionStatus(value.Status),
-			Timestamp:   value.Timestamp,
-		})
+	for i := range auctionEntities {
+		auctionOutputs = append(auctionOutputs, newAuctionOutputDTO(&auctionEntities[i], now))
 	}
 
 	return auctionOutputs, nil
@@ -60,15 +61,7 @@ func (au *AuctionUseCase) FindWinningBidByAuctionId(
 		return nil, err
 	}
 
-	auctionOutputDTO := AuctionOutputDTO{
-		Id:          auction.Id,
-		ProductName: auction.ProductName,
-		Category:    auction.Category,
-		Description: auction.Description,
-		Condition:   ProductCondition(auction.Condition),
-		Status:      AuctionStatus(auction.Status),
-		Timestamp:   auction.Timestamp,
-	}
+	auctionOutputDTO := newAuctionOutputDTO(auction, time.Now())
 
 	bidWinning, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auction.Id)
 	if err != nil {
@@ -92,3 +85,33 @@ func (au *AuctionUseCase) FindWinningBidByAuctionId(
 		Bid:     bidOutputDTO,
 	}, nil
 }
+
+func newAuctionOutputDTO(auction *auction_entity.Auction, now time.Time) AuctionOutputDTO {
+	auctionOutputDTO := AuctionOutputDTO{
+		Id:               auction.Id,
+		SellerId:         auction.SellerId,
+		ProductName:      auction.ProductName,
+		Category:         auction.Category,
+		Categories:       auction.Categories,
+		Description:      auction.Description,
+		Condition:        ProductCondition(auction.Condition),
+		Status:           AuctionStatus(auction.Status),
+		Timestamp:        auction.Timestamp,
+		RemainingSeconds: auction.RemainingSeconds(now),
+		BuyNowPrice:      auction.BuyNowPrice,
+		StartingBid:      auction.StartingBid,
+		ReserveNotMet:    auction.ReserveNotMet,
+	}
+
+	if !auction.EndTime.IsZero() {
+		endTime := auction.EndTime
+		auctionOutputDTO.EndTime = &endTime
+	}
+
+	if !auction.StartTime.IsZero() {
+		startTime := auction.StartTime
+		auctionOutputDTO.StartTime = &startTime
+	}
+
+	return auctionOutputDTO
+}
@@ -2,11 +2,14 @@ package bid_usecase
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +17,7 @@ type BidInputDTO struct {
 	UserId    string  `json:"user_id"`
 	AuctionId string  `json:"auction_id"`
 	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
 }
 
 type BidOutputDTO struct {
@@ -21,11 +25,14 @@ type BidOutputDTO struct {
 	UserId    string    `json:"user_id"`
 	AuctionId string    `json:"auction_id"`
 	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency,omitempty"`
 	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
 }
 
 type BidUseCase struct {
 	BidRepository bid_entity.BidEntityRepository
+	// Quando configurado, apenas usuários verificados podem dar lances
+	userVerifier user_entity.UserVerifier
 
 	timer               *time.Timer
 	maxBatchSize        int
@@ -33,12 +40,15 @@ type BidUseCase struct {
 	bidChannel          chan bid_entity.Bid
 }
 
-func NewBidUseCase(bidRepository bid_entity.BidEntityRepository) BidUseCaseInterface {
+func NewBidUseCase(
+	bidRepository bid_entity.BidEntityRepository,
+	userVerifier user_entity.UserVerifier) BidUseCaseInterface {
 	maxSizeInterval := getMaxBatchSizeInterval()
 	maxBatchSize := getMaxBatchSize()
 
 	bidUseCase := &BidUseCase{
 		BidRepository:       bidRepository,
+		userVerifier:        userVerifier,
 		maxBatchSize:        maxBatchSize,
 		batchInsertInterval: maxSizeInterval,
 		timer:               time.NewTimer(maxSizeInterval),
@@ -61,7 +71,7 @@ type BidUseCaseInterface interface {
 		ctx context.Context, auctionId string) (*BidOutputDTO, *internal_error.InternalError)
 
 	FindBidByAuctionId(
-		ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError)
+		ctx context.Context, auctionId string, limit int64, ascending bool) ([]BidOutputDTO, *internal_error.InternalError)
 }
 
 func (bu *BidUseCase) triggerCreateRoutine(ctx context.Context) {
@@ -110,11 +120,43 @@ func (bu *BidUseCase) CreateBid(
 		return err
 	}
 
+	bidEntity.Currency = strings.ToUpper(strings.TrimSpace(bidInputDTO.Currency))
+
+	if err := bu.checkUserVerified(ctx, bidEntity.UserId); err != nil {
+		return err
+	}
+
+	// Lances abaixo do lance inicial ou que não superam o maior lance são
+	// recusados antes do lote, para que o erro chegue ao controller
+	if err := bu.BidRepository.CheckBidAmount(ctx, *bidEntity); err != nil {
+		return err
+	}
+
 	bu.bidChannel <- *bidEntity
 
 	return nil
 }
 
+// Sem verificador configurado, todos os usuários podem dar lances
+func (bu *BidUseCase) checkUserVerified(ctx context.Context, userId string) *internal_error.InternalError {
+	if bu.userVerifier == nil {
+		return nil
+	}
+
+	verified, err := bu.userVerifier.IsVerified(ctx, userId)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to verify user %s", userId), err)
+		return internal_error.NewInternalServerError("Error trying to verify user")
+	}
+
+	if !verified {
+		return internal_error.NewBadRequestError("Only verified users can place bids").
+			WithCode("user.not_verified")
+	}
+
+	return nil
+}
+
 func getMaxBatchSizeInterval() time.Duration {
 	batchInsertInterval := os.Getenv("BATCH_INSERT_INTERVAL")
 	duration, err := time.ParseDuration(batchInsertInterval)
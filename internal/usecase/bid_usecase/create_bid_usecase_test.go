@@ -0,0 +1,122 @@
+package bid_usecase
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type fakeUserVerifier struct {
+	verified map[string]bool
+	err      error
+}
+
+func (f *fakeUserVerifier) IsVerified(ctx context.Context, userId string) (bool, error) {
+	return f.verified[userId], f.err
+}
+
+// Repositório que aceita qualquer valor, a menos que rejection seja
+// informado
+type fakeBidRepository struct {
+	bid_entity.BidEntityRepository
+	rejection *internal_error.InternalError
+}
+
+func (f *fakeBidRepository) CheckBidAmount(ctx context.Context, bid bid_entity.Bid) *internal_error.InternalError {
+	return f.rejection
+}
+
+func newTestBidUseCase(verifier *fakeUserVerifier) *BidUseCase {
+	useCase := &BidUseCase{
+		BidRepository: &fakeBidRepository{},
+		bidChannel:    make(chan bid_entity.Bid, 1),
+	}
+	if verifier != nil {
+		useCase.userVerifier = verifier
+	}
+	return useCase
+}
+
+func TestCreateBidUserVerification(t *testing.T) {
+	verifiedUser := uuid.New().String()
+	unverifiedUser := uuid.New().String()
+	verifier := &fakeUserVerifier{verified: map[string]bool{verifiedUser: true}}
+
+	testCases := []struct {
+		name     string
+		verifier *fakeUserVerifier
+		userId   string
+		code     string
+	}{
+		{"verified user", verifier, verifiedUser, ""},
+		{"unverified user", verifier, unverifiedUser, "user.not_verified"},
+		{"no verifier configured", nil, unverifiedUser, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			useCase := newTestBidUseCase(tc.verifier)
+
+			err := useCase.CreateBid(context.Background(), BidInputDTO{
+				UserId: tc.userId, AuctionId: uuid.New().String(), Amount: 10})
+
+			if tc.code == "" {
+				if err != nil {
+					t.Fatalf("Expected bid to be accepted, got %v", err)
+				}
+				if len(useCase.bidChannel) != 1 {
+					t.Errorf("Expected bid to be queued")
+				}
+				return
+			}
+
+			if err == nil || err.Code != tc.code {
+				t.Fatalf("Expected %s, got %v", tc.code, err)
+			}
+			if len(useCase.bidChannel) != 0 {
+				t.Errorf("Expected rejected bid not to be queued")
+			}
+		})
+	}
+}
+
+func TestCreateBidVerifierFailure(t *testing.T) {
+	useCase := newTestBidUseCase(&fakeUserVerifier{err: errors.New("users unavailable")})
+
+	err := useCase.CreateBid(context.Background(), BidInputDTO{
+		UserId: uuid.New().String(), AuctionId: uuid.New().String(), Amount: 10})
+
+	if err == nil || err.Err != "internal_server_error" {
+		t.Errorf("Expected internal_server_error when verification fails, got %v", err)
+	}
+}
+
+func TestCreateBidReturnsAmountRejection(t *testing.T) {
+	rejections := []*internal_error.InternalError{
+		internal_error.NewBadRequestError("Bid must be greater than the current highest bid of 20.00").
+			WithCode("bid.too_low"),
+		internal_error.NewBadRequestError("Bid must be at least the starting bid of 50.00").
+			WithCode("bid.below_starting_bid"),
+	}
+
+	for _, rejection := range rejections {
+		t.Run(rejection.Code, func(t *testing.T) {
+			useCase := newTestBidUseCase(nil)
+			useCase.BidRepository = &fakeBidRepository{rejection: rejection}
+
+			err := useCase.CreateBid(context.Background(), BidInputDTO{
+				UserId: uuid.New().String(), AuctionId: uuid.New().String(), Amount: 10})
+
+			if err == nil || err.Code != rejection.Code {
+				t.Fatalf("Expected %s to be returned synchronously, got %v", rejection.Code, err)
+			}
+			if len(useCase.bidChannel) != 0 {
+				t.Errorf("Expected rejected bid not to be queued")
+			}
+		})
+	}
+}
@@ -2,12 +2,17 @@ package bid_usecase
 
 import (
 	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
 )
 
 func (bu *BidUseCase) FindBidByAuctionId(
-	ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError) {
-	bidList, err := bu.BidRepository.FindBidByAuctionId(ctx, auctionId)
+	ctx context.Context,
+	auctionId string,
+	limit int64,
+	ascending bool) ([]BidOutputDTO, *internal_error.InternalError) {
+	bidList, err := bu.BidRepository.FindBidByAuctionId(ctx, auctionId,
+		bid_entity.FindBidsOptions{Limit: limit, Ascending: ascending})
 	if err != nil {
 		return nil, err
 	}
@@ -19,6 +24,7 @@ func (bu *BidUseCase) FindBidByAuctionId(
 			UserId:    bid.UserId,
 			AuctionId: bid.AuctionId,
 			Amount:    bid.Amount,
+			Currency:  bid.Currency,
 			Timestamp: bid.Timestamp,
 		})
 	}
@@ -38,6 +44,7 @@ func (bu *BidUseCase) FindWinningBidByAuctionId(
 		UserId:    bidEntity.UserId,
 		AuctionId: bidEntity.AuctionId,
 		Amount:    bidEntity.Amount,
+		Currency:  bidEntity.Currency,
 		Timestamp: bidEntity.Timestamp,
 	}
 